@@ -0,0 +1,99 @@
+package xiter
+
+import (
+	"context"
+	"iter"
+)
+
+// FromChan returns an iterator that yields the values received from the given
+// channel until it is closed. Stopping the iterator early does not close or
+// drain the channel, and the remaining values are left for other receivers.
+func FromChan[T any](ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for t := range ch {
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// ToChan starts a goroutine that sends each element of the input iterator to
+// the returned channel, which has a buffer of the given size. The channel is
+// closed once the iterator is exhausted, or once the context is done, at which
+// point the input iterator is stopped. A receiver that abandons the channel
+// must cancel the context to avoid leaking the goroutine.
+//
+// As the input iterator runs on its own goroutine, a panic while iterating
+// will not be recoverable by the receiver.
+func ToChan[T any](ctx context.Context, it iter.Seq[T], buf int) <-chan T {
+	ch := make(chan T, buf)
+	go func() {
+		defer close(ch)
+		for t := range it {
+			select {
+			case ch <- t:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// prefetched is an element sent from the producer of a Prefetch iterator, or
+// the value of a panic raised by the input iterator.
+type prefetched[T any] struct {
+	value    T
+	panicked bool
+	panicVal any
+}
+
+// Prefetch returns an iterator that yields the same values as the input
+// iterator, which is run on a separate goroutine up to n elements ahead of
+// the consumer. This lets a slow producer and slow consumer work at the same
+// time. A panic in the input iterator is re-raised on the consumer's
+// goroutine, and the producer goroutine has always exited by the time
+// iteration finishes, including when the consumer stops early. Prefetch
+// panics if n is negative.
+func Prefetch[T any](it iter.Seq[T], n int) iter.Seq[T] {
+	if n < 0 {
+		panic("xiter: Prefetch: n must not be negative")
+	}
+	return func(yield func(T) bool) {
+		ch := make(chan prefetched[T], n)
+		done := make(chan struct{})
+		go func() {
+			defer close(ch)
+			defer func() {
+				if r := recover(); r != nil {
+					select {
+					case ch <- prefetched[T]{panicked: true, panicVal: r}:
+					case <-done:
+					}
+				}
+			}()
+			for t := range it {
+				select {
+				case ch <- prefetched[T]{value: t}:
+				case <-done:
+					return
+				}
+			}
+		}()
+		defer func() {
+			close(done)
+			for range ch {
+			}
+		}()
+
+		for p := range ch {
+			if p.panicked {
+				panic(p.panicVal)
+			}
+			if !yield(p.value) {
+				return
+			}
+		}
+	}
+}
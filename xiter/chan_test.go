@@ -0,0 +1,107 @@
+package xiter
+
+import (
+	"context"
+	"iter"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// chanOf returns a closed channel holding the given values.
+func chanOf[T any](values ...T) chan T {
+	ch := make(chan T, len(values))
+	for _, v := range values {
+		ch <- v
+	}
+	close(ch)
+	return ch
+}
+
+func TestFromChan(t *testing.T) {
+	TestSuite{
+		SliceCollectTest("empty", FromChan(chanOf[int]()), nil),
+		SliceCollectTest("some", FromChan(chanOf(1, 2, 3)), list(1, 2, 3)),
+		SliceCollectTest("limited", Limit(FromChan(chanOf(1, 2, 3)), 2), list(1, 2)),
+	}.Run(t)
+
+	ch := chanOf(1, 2, 3)
+	First(FromChan(ch))
+	assert.Equal(t, list(2, 3), slices.Collect(FromChan(ch)), "remaining values left in channel")
+}
+
+func TestToChan(t *testing.T) {
+	ch := ToChan(context.Background(), Range(0, 5), 2)
+	assert.Equal(t, slices.Collect(Range(0, 5)), slices.Collect(FromChan(ch)), "same sequence")
+
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch = ToChan(ctx, Count(0), 0)
+	assert.Equal(t, list(0, 1, 2), slices.Collect(Limit(FromChan(ch), 3)))
+	cancel()
+	for range ch {
+	}
+	assert.LessOrEqual(t, waitForGoroutines(before), before, "producer has exited")
+}
+
+func TestPrefetch(t *testing.T) {
+	TestSuite{
+		SliceCollectTest("empty", Prefetch(slices.Values([]int{}), 2), nil),
+		SliceCollectTest("some", Prefetch(Range(0, 5), 2), list(0, 1, 2, 3, 4)),
+		SliceCollectTest("unbuffered", Prefetch(Range(0, 5), 0), list(0, 1, 2, 3, 4)),
+		SliceCollectTest("infinite", Limit(Prefetch(Count(0), 4), 3), list(0, 1, 2)),
+
+		SimpleTest("negative", func(t *testing.T) iter.Seq[int] {
+			return Prefetch(Range(0, 5), -1)
+		}).PanicsWith("xiter: Prefetch: n must not be negative"),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			return Prefetch(s, 2)
+		}),
+	}.Run(t)
+}
+
+func TestPrefetchCleanup(t *testing.T) {
+	before := runtime.NumGoroutine()
+	src, stopped := stopTracker(1, 2, 3, 4, 5)
+	for range Prefetch(src, 1) {
+		break
+	}
+	assert.True(t, *stopped, "source stopped")
+	assert.LessOrEqual(t, waitForGoroutines(before), before, "producer has exited")
+}
+
+func TestPrefetchOverlaps(t *testing.T) {
+	// The source reports each value it produces, so that the consumer can
+	// check the producer keeps running while the consumer is still busy with
+	// the first value.
+	produced := make(chan int, 5)
+	src := func(yield func(int) bool) {
+		for i := range 5 {
+			produced <- i
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	var got []int
+	for i := range Prefetch(src, 5) {
+		if i != 0 {
+			continue
+		}
+		timeout := time.After(5 * time.Second)
+	wait:
+		for range 5 {
+			select {
+			case p := <-produced:
+				got = append(got, p)
+			case <-timeout:
+				break wait
+			}
+		}
+	}
+	assert.Equal(t, list(0, 1, 2, 3, 4), got, "producer runs ahead of the consumer")
+}
@@ -0,0 +1,83 @@
+package xiter
+
+import "iter"
+
+// maxChunkPrealloc is the most elements Chunk allocates room for before they
+// arrive, so a large n doesn't waste memory on a short input.
+const maxChunkPrealloc = 1024
+
+// Chunk returns an iterator that yields consecutive, non-overlapping slices of
+// n elements from the input iterator. The final slice may contain fewer than n
+// elements if the input runs out. Each yielded slice is newly allocated, and
+// may be retained by the caller. Chunk panics if n is less than 1.
+func Chunk[T any](it iter.Seq[T], n int) iter.Seq[[]T] {
+	if n < 1 {
+		panic("xiter: Chunk: n must be at least 1")
+	}
+	return func(yield func([]T) bool) {
+		var chunk []T
+		for t := range it {
+			if chunk == nil {
+				chunk = make([]T, 0, min(n, maxChunkPrealloc))
+			}
+			chunk = append(chunk, t)
+			if len(chunk) == n {
+				if !yield(chunk) {
+					return
+				}
+				chunk = nil
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Window returns an iterator that yields overlapping slices of the most recent
+// n elements from the input iterator, advancing by one element at a time. No
+// slices are yielded if the input has fewer than n elements.
+//
+// The windows are stored in a single ring buffer which is reused from step to
+// step, so a yielded slice is only valid until the next one is requested. Use
+// slices.Clone to retain a window. Window panics if n is less than 1.
+func Window[T any](it iter.Seq[T], n int) iter.Seq[[]T] {
+	if n < 1 {
+		panic("xiter: Window: n must be at least 1")
+	}
+	return func(yield func([]T) bool) {
+		// Every element is written twice, n positions apart, so that the
+		// latest n elements are always available as a contiguous slice.
+		buf := make([]T, 2*n)
+		count := 0
+		for t := range it {
+			i := count % n
+			buf[i], buf[i+n] = t, t
+			if count++; count >= n && !yield(buf[i+1:i+1+n:i+1+n]) {
+				return
+			}
+		}
+	}
+}
+
+// ChunkBy returns an iterator that yields slices of consecutive elements from
+// the input iterator. A new slice is started whenever the function split
+// returns true when given the previous element and the current one. Each
+// yielded slice is newly allocated, and may be retained by the caller.
+func ChunkBy[T any](it iter.Seq[T], split func(prev, cur T) bool) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		var chunk []T
+		for t := range it {
+			if len(chunk) > 0 && split(chunk[len(chunk)-1], t) {
+				if !yield(chunk) {
+					return
+				}
+				chunk = nil
+			}
+			chunk = append(chunk, t)
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package xiter
+
+import (
+	"iter"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunk(t *testing.T) {
+	nums := list(1, 2, 3, 4, 5)
+	TestSuite{
+		SliceCollectTest("empty", Chunk(slices.Values([]int{}), 2), nil),
+		SliceCollectTest("exact", Chunk(slices.Values(nums[:4]), 2), list(list(1, 2), list(3, 4))),
+		SliceCollectTest("short", Chunk(slices.Values(nums), 2), list(list(1, 2), list(3, 4), list(5))),
+		SliceCollectTest("one", Chunk(slices.Values(nums[:3]), 1), list(list(1), list(2), list(3))),
+		SliceCollectTest("large", Chunk(slices.Values(nums), 10), list(nums)),
+		SliceCollectTest("infinite", Limit(Chunk(Count(0), 3), 2), list(list(0, 1, 2), list(3, 4, 5))),
+		SliceCollectTest("huge", Chunk(slices.Values(nums), math.MaxInt), list(nums)),
+		SliceCollectTest("pastPrealloc", Map(Chunk(Range(0, 3000), 2500), func(c []int) int { return len(c) }), list(2500, 500)),
+
+		SimpleTest("zeroSize", func(t *testing.T) iter.Seq[[]int] {
+			return Chunk(slices.Values(nums), 0)
+		}).PanicsWith("xiter: Chunk: n must be at least 1"),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[[]int] {
+			return Chunk(s, 2)
+		}),
+	}.Run(t)
+}
+
+func TestWindow(t *testing.T) {
+	nums := list(1, 2, 3, 4, 5)
+	clone := func(it iter.Seq[[]int]) iter.Seq[[]int] { return Map(it, slices.Clone) }
+	TestSuite{
+		SliceCollectTest("empty", clone(Window(slices.Values([]int{}), 2)), nil),
+		SliceCollectTest("tooShort", clone(Window(slices.Values(nums[:2]), 3)), nil),
+		SliceCollectTest("exact", clone(Window(slices.Values(nums[:3]), 3)), list(list(1, 2, 3))),
+		SliceCollectTest("pairs", clone(Window(slices.Values(nums), 2)), list(list(1, 2), list(2, 3), list(3, 4), list(4, 5))),
+		SliceCollectTest("triples", clone(Window(slices.Values(nums), 3)), list(list(1, 2, 3), list(2, 3, 4), list(3, 4, 5))),
+		SliceCollectTest("one", clone(Window(slices.Values(nums[:3]), 1)), list(list(1), list(2), list(3))),
+		SliceCollectTest("infinite", clone(Limit(Window(Count(0), 2), 3)), list(list(0, 1), list(1, 2), list(2, 3))),
+
+		SimpleTest("zeroSize", func(t *testing.T) iter.Seq[[]int] {
+			return Window(slices.Values(nums), 0)
+		}).PanicsWith("xiter: Window: n must be at least 1"),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[[]int] {
+			return Window(s, 2)
+		}),
+	}.Run(t)
+}
+
+func TestWindowAllocations(t *testing.T) {
+	sum := 0
+	allocs := func(n int) float64 {
+		return testing.AllocsPerRun(10, func() {
+			for w := range Window(Range(0, n), 3) {
+				sum += w[0]
+			}
+		})
+	}
+	assert.Equal(t, allocs(10), allocs(1000), "allocations don't grow with the input")
+}
+
+func TestChunkBy(t *testing.T) {
+	ascending := func(prev, cur int) bool { return cur < prev }
+	TestSuite{
+		SliceCollectTest("empty", ChunkBy(slices.Values([]int{}), ascending), nil),
+		SliceCollectTest("single", ChunkBy(slices.Values(list(1)), ascending), list(list(1))),
+		SliceCollectTest("runs", ChunkBy(slices.Values(list(1, 2, 3, 1, 2, 0)), ascending), list(list(1, 2, 3), list(1, 2), list(0))),
+		SliceCollectTest("never", ChunkBy(slices.Values(list(3, 2, 1)), func(int, int) bool { return false }), list(list(3, 2, 1))),
+		SliceCollectTest("limited", Limit(ChunkBy(Count(0), func(_, cur int) bool { return cur%2 == 0 }), 2), list(list(0, 1), list(2, 3))),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[[]int] {
+			return ChunkBy(s, ascending)
+		}),
+	}.Run(t)
+}
@@ -0,0 +1,48 @@
+package xiter
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"io"
+)
+
+// Codec describes how to write a stream of values of type T to bytes, and read
+// them back again. It is used by the functions that store values in files,
+// such as SortExternal and MaterializeToDisk.
+type Codec[T any] interface {
+	// Encoder returns a function that writes each value it is given to w.
+	Encoder(w io.Writer) func(T) error
+	// Decoder returns a function that reads the next value from r into its
+	// argument. It returns io.EOF once there are no more values.
+	Decoder(r io.Reader) func(*T) error
+}
+
+// GobCodec is a Codec that uses encoding/gob.
+type GobCodec[T any] struct{}
+
+// Encoder returns a function that writes values to w using a gob.Encoder.
+func (GobCodec[T]) Encoder(w io.Writer) func(T) error {
+	enc := gob.NewEncoder(w)
+	return func(t T) error { return enc.Encode(t) }
+}
+
+// Decoder returns a function that reads values from r using a gob.Decoder.
+func (GobCodec[T]) Decoder(r io.Reader) func(*T) error {
+	dec := gob.NewDecoder(r)
+	return func(t *T) error { return dec.Decode(t) }
+}
+
+// JSONCodec is a Codec that uses encoding/json, writing one value per line.
+type JSONCodec[T any] struct{}
+
+// Encoder returns a function that writes values to w using a json.Encoder.
+func (JSONCodec[T]) Encoder(w io.Writer) func(T) error {
+	enc := json.NewEncoder(w)
+	return func(t T) error { return enc.Encode(t) }
+}
+
+// Decoder returns a function that reads values from r using a json.Decoder.
+func (JSONCodec[T]) Decoder(r io.Reader) func(*T) error {
+	dec := json.NewDecoder(r)
+	return func(t *T) error { return dec.Decode(t) }
+}
@@ -1,6 +1,10 @@
 package xiter
 
-import "iter"
+import (
+	"iter"
+
+	"github.com/cookieo9/go-std-addons/option"
+)
 
 // Collect is a higher-order function that applies a function f to update an
 // accumulated value using each element of an iterator. It starts with an
@@ -13,3 +17,63 @@ func Collect[T, Accum any](it iter.Seq[T], start Accum, f func(Accum, T) Accum)
 	}
 	return out
 }
+
+// Collect2 is the iter.Seq2 counterpart of Collect. It applies a function f to
+// update an accumulated value using each pair of elements of an iterator,
+// starting with the initial value of the accumulator, and returns the final
+// accumulated value.
+func Collect2[K, V, Accum any](it iter.Seq2[K, V], start Accum, f func(Accum, K, V) Accum) Accum {
+	out := start
+	for k, v := range it {
+		out = f(out, k, v)
+	}
+	return out
+}
+
+// Reduce is a variant of Collect that uses the first element of the iterator
+// as the initial accumulated value, combining it with each of the remaining
+// elements using the function f. The result is returned as an option.Value,
+// which is not present if the iterator has no elements.
+func Reduce[T any](it iter.Seq[T], f func(T, T) T) option.Value[T] {
+	var out T
+	ok := false
+	for t := range it {
+		if ok {
+			out = f(out, t)
+		} else {
+			out, ok = t, true
+		}
+	}
+	return option.Of(out, ok)
+}
+
+// Scan is a variant of Collect that returns an iterator yielding each
+// intermediate accumulated value, rather than just the final one. The initial
+// accumulator value start is not yielded, so an empty iterator results in an
+// empty output.
+func Scan[T, Accum any](it iter.Seq[T], start Accum, f func(Accum, T) Accum) iter.Seq[Accum] {
+	return func(yield func(Accum) bool) {
+		out := start
+		for t := range it {
+			out = f(out, t)
+			if !yield(out) {
+				return
+			}
+		}
+	}
+}
+
+// FoldWhile is a variant of Collect that can stop early. The function f returns
+// the updated accumulated value, along with a boolean indicating whether to
+// continue. Once f returns false, the iterator is stopped and the value
+// returned with it is the final result.
+func FoldWhile[T, Accum any](it iter.Seq[T], start Accum, f func(Accum, T) (Accum, bool)) Accum {
+	out := start
+	for t := range it {
+		var more bool
+		if out, more = f(out, t); !more {
+			break
+		}
+	}
+	return out
+}
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/cookieo9/go-std-addons/option"
 )
 
 func collectTestCase[T, Accum any](name string, source []T, start Accum, f func(Accum, T) Accum, want Accum) TestCase {
@@ -44,3 +46,88 @@ func TestCollect(t *testing.T) {
 		}),
 	}.Run(t)
 }
+
+func TestCollect2(t *testing.T) {
+	weighted := func(accum int, i, v int) int { return accum + i*v }
+	collect2TestCase := func(name string, source []int, start int, want int) TestCase {
+		return SimpleTest(name, func(t *testing.T) int {
+			return Collect2(slices.All(source), start, weighted)
+		}).Compare(want, assert.Equal).Args("same result")
+	}
+	TestSuite{
+		collect2TestCase("emptyNil", nil, 0, 0),
+		collect2TestCase("emptyStart10", []int{}, 10, 10),
+		collect2TestCase("some", []int{1, 2, 3}, 0, 8),
+		collect2TestCase("someStart10", []int{1, 2, 3}, 10, 18),
+
+		PanicTestCases2(func(f iter.Seq2[int, int]) iter.Seq2[int, int] {
+			Collect2(f, 0, weighted)
+			return f
+		}),
+	}.Run(t)
+}
+
+func TestReduce(t *testing.T) {
+	sum := func(a, b int) int { return a + b }
+	reduceTestCase := func(name string, src []int, want option.Value[int]) TestCase {
+		return SimpleTest(name, func(t *testing.T) option.Value[int] {
+			return Reduce(slices.Values(src), sum)
+		}).Compare(want, assert.Equal).Args("same result")
+	}
+	TestSuite{
+		reduceTestCase("nil", nil, option.None[int]()),
+		reduceTestCase("empty", []int{}, option.None[int]()),
+		reduceTestCase("one", list(5), option.Some(5)),
+		reduceTestCase("zero", list(0), option.Some(0)),
+		reduceTestCase("some", list(1, 2, 3), option.Some(6)),
+		SimpleTest("order", func(t *testing.T) option.Value[string] {
+			return Reduce(slices.Values(list("a", "b", "c")), func(a, b string) string { return "(" + a + b + ")" })
+		}).Compare(option.Some("((ab)c)"), assert.Equal),
+
+		PanicTestCases(func(f iter.Seq[int]) iter.Seq[int] {
+			Reduce(f, sum)
+			return f
+		}),
+	}.Run(t)
+}
+
+func TestScan(t *testing.T) {
+	sum := func(a, b int) int { return a + b }
+	TestSuite{
+		SliceCollectTest("nil", Scan(slices.Values([]int(nil)), 0, sum), nil),
+		SliceCollectTest("some", Scan(slices.Values(list(1, 2, 3)), 0, sum), list(1, 3, 6)),
+		SliceCollectTest("start10", Scan(slices.Values(list(1, 2, 3)), 10, sum), list(11, 13, 16)),
+		SliceCollectTest("lengths", Scan(slices.Values(list("a", "bb")), 0, func(n int, s string) int { return n + len(s) }), list(1, 3)),
+		SliceCollectTest("infinite", Limit(Scan(Count(1), 0, sum), 4), list(1, 3, 6, 10)),
+
+		PanicTestCases(func(f iter.Seq[int]) iter.Seq[int] {
+			return Scan(f, 0, sum)
+		}),
+	}.Run(t)
+}
+
+func TestFoldWhile(t *testing.T) {
+	sumBelow10 := func(accum, t int) (int, bool) {
+		if accum+t >= 10 {
+			return accum, false
+		}
+		return accum + t, true
+	}
+	foldTestCase := func(name string, it iter.Seq[int], start, want int) TestCase {
+		return SimpleTest(name, func(t *testing.T) int {
+			return FoldWhile(it, start, sumBelow10)
+		}).Compare(want, assert.Equal).Args("same result")
+	}
+	TestSuite{
+		foldTestCase("nil", slices.Values([]int(nil)), 0, 0),
+		foldTestCase("start5", slices.Values([]int{}), 5, 5),
+		foldTestCase("all", slices.Values(list(1, 2, 3)), 0, 6),
+		foldTestCase("stops", slices.Values(list(4, 5, 6, 1)), 0, 9),
+		foldTestCase("infinite", Count(1), 0, 6),
+
+		PanicTestCases(func(f iter.Seq[int]) iter.Seq[int] {
+			FoldWhile(f, 0, sumBelow10)
+			return f
+		}),
+	}.Run(t)
+}
@@ -0,0 +1,55 @@
+package xiter
+
+import (
+	"context"
+	"iter"
+)
+
+// WithContext returns an iterator that yields the elements of the input
+// iterator until the given context is done. The context is checked before
+// iteration starts and before each element is yielded. Once the context is
+// done the input iterator is stopped, and the iterator panics with the value
+// of ctx.Err(), so that the cancellation can be reported by xerrors.Catch.
+//
+// WithContext can't interrupt an input iterator that is blocked while
+// producing an element, so the cancellation is only noticed once the element
+// has been produced.
+func WithContext[T any](ctx context.Context, it iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		checkContext(ctx)
+		for t := range it {
+			checkContext(ctx)
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// checkContext panics with the context's error if it is done.
+func checkContext(ctx context.Context) {
+	if err := ctx.Err(); err != nil {
+		panic(err)
+	}
+}
+
+// ForeverContext is a context-aware version of Forever. It yields the provided
+// value t until the context is done, at which point it panics with the value
+// of ctx.Err().
+func ForeverContext[T any](ctx context.Context, t T) iter.Seq[T] {
+	return WithContext(ctx, Forever(t))
+}
+
+// RepeatContext is a context-aware version of Repeat. It yields the provided
+// value t n times, panicking with the value of ctx.Err() if the context is
+// done first.
+func RepeatContext[T any](ctx context.Context, t T, n int) iter.Seq[T] {
+	return WithContext(ctx, Repeat(t, n))
+}
+
+// CountContext is a context-aware version of Count. It yields successive
+// values from start until the context is done, at which point it panics with
+// the value of ctx.Err().
+func CountContext[T Countable](ctx context.Context, start T) iter.Seq[T] {
+	return WithContext(ctx, Count(start))
+}
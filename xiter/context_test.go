@@ -0,0 +1,61 @@
+package xiter
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cookieo9/go-std-addons/xerrors"
+)
+
+func TestWithContext(t *testing.T) {
+	ctx := context.Background()
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	TestSuite{
+		SliceCollectTest("empty", WithContext(ctx, slices.Values([]int{})), nil),
+		SliceCollectTest("some", WithContext(ctx, Range(0, 3)), list(0, 1, 2)),
+		SliceCollectTest("limited", Limit(CountContext(ctx, 0), 3), list(0, 1, 2)),
+		SliceCollectTest("repeat", RepeatContext(ctx, "x", 2), list("x", "x")),
+		SliceCollectTest("forever", Limit(ForeverContext(ctx, 1.5), 2), list(1.5, 1.5)),
+
+		SliceCollectTest("cancelled", WithContext(cancelled, slices.Values([]int{})), nil).
+			PanicsError(context.Canceled),
+		SliceCollectTest("cancelledForever", ForeverContext(cancelled, 1), nil).
+			PanicsError(context.Canceled),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			return WithContext(ctx, s)
+		}),
+	}.Run(t)
+}
+
+func TestWithContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	src, stopped := stopTracker(1, 2, 3, 4)
+	var got []int
+	err := xerrors.Catch(func() {
+		for v := range WithContext(ctx, src) {
+			got = append(got, v)
+			if v == 2 {
+				cancel()
+			}
+		}
+	})
+	assert.ErrorIs(t, err, context.Canceled, "cancellation reported")
+	assert.Equal(t, list(1, 2), got, "no values after cancellation")
+	assert.True(t, *stopped, "source stopped")
+}
+
+func TestCountContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := xerrors.CatchValue(func() int {
+		return Sum(CountContext(ctx, 0))
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "deadline reported")
+}
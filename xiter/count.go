@@ -38,6 +38,36 @@ func CountDown[T Countable](start, step T) iter.Seq[T] {
 	}
 }
 
+// CountUpChecked returns an iterator that yields successive values starting
+// from the given start value, incrementing by the given step each time. Unlike
+// CountUp it will not wrap on overflow, instead stopping after the last value
+// that can be represented. For floating point types it stops once adding the
+// step no longer increases the value, such as at infinity.
+func CountUpChecked[T Countable](start, step T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := start; yield(i); i += step {
+			if i+step <= i {
+				return
+			}
+		}
+	}
+}
+
+// CountDownChecked returns an iterator that yields successive values starting
+// from the given start value, decrementing by the given step each time. Unlike
+// CountDown it will not wrap on underflow, instead stopping after the last
+// value that can be represented. For floating point types it stops once
+// subtracting the step no longer decreases the value.
+func CountDownChecked[T Countable](start, step T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := start; yield(i); i -= step {
+			if i-step >= i {
+				return
+			}
+		}
+	}
+}
+
 // Range returns an iterator that yields successive values from start to finish
 // (exclusive) with a step of 1. It is equivalent to calling RangeBy with a
 // step of 1.
@@ -48,10 +78,69 @@ func Range[T Countable](start, end T) iter.Seq[T] {
 // RangeBy returns an iterator that yields successive values from start to end
 // with the given step size. If start is less than end, the iterator counts up,
 // otherwise it counts down. When counting up, the iterator will stop when
-// i >= end, and when counting down, it will stop when i <= end.
+// i >= end, and when counting down, it will stop when i <= end. The iterator
+// also stops rather than wrapping if a step would overflow the type.
+//
+// For floating point types each value is computed as start + n*step, rather
+// than by repeated addition, so that rounding errors don't accumulate.
 func RangeBy[T Countable](start, end, step T) iter.Seq[T] {
 	if start < end {
-		return While(CountUp(start, step), func(i T) bool { return i < end })
+		return While(rangeSteps(start, step, true), func(i T) bool { return i < end })
+	}
+	return While(rangeSteps(start, step, false), func(i T) bool { return i > end })
+}
+
+// RangeInclusive returns an iterator that yields successive values from start
+// to end (inclusive) with a step of 1. It is equivalent to calling
+// RangeByInclusive with a step of 1.
+func RangeInclusive[T Countable](start, end T) iter.Seq[T] {
+	return RangeByInclusive(start, end, 1)
+}
+
+// RangeByInclusive is like RangeBy, except that end is included in the output
+// if it is reached by a whole number of steps. When counting up, the iterator
+// will stop when i > end, and when counting down, it will stop when i < end.
+// This allows ranges that end at the maximum or minimum value of a type, which
+// can't be expressed with RangeBy.
+func RangeByInclusive[T Countable](start, end, step T) iter.Seq[T] {
+	if start <= end {
+		return While(rangeSteps(start, step, true), func(i T) bool { return i <= end })
+	}
+	return While(rangeSteps(start, step, false), func(i T) bool { return i >= end })
+}
+
+// rangeSteps returns an unbounded iterator of the values visited by a range
+// starting at start, that stops instead of wrapping on overflow.
+func rangeSteps[T Countable](start, step T, up bool) iter.Seq[T] {
+	var one T = 1
+	if one/2 == 0 {
+		if up {
+			return CountUpChecked(start, step)
+		}
+		return CountDownChecked(start, step)
+	}
+	if !up {
+		step = -step
+	}
+	return Map(Count(0), func(n int) T { return start + T(n)*step })
+}
+
+// Linspace returns an iterator that yields n evenly spaced values from start to
+// end, inclusive. The first value is exactly start, and the last is exactly
+// end. If n is 1, only start is yielded, and if n is less than 1 nothing is
+// yielded.
+func Linspace[T ~float32 | ~float64](start, end T, n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range n {
+			v := start
+			if i == n-1 && i > 0 {
+				v = end
+			} else if i > 0 {
+				v = start + (end-start)*T(i)/T(n-1)
+			}
+			if !yield(v) {
+				return
+			}
+		}
 	}
-	return While(CountDown(start, step), func(i T) bool { return i > end })
 }
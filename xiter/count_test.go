@@ -2,6 +2,7 @@ package xiter
 
 import (
 	"iter"
+	"math"
 	"slices"
 	"testing"
 
@@ -56,6 +57,67 @@ func TestRanges(t *testing.T) {
 	}.Run(t)
 }
 
+func TestRangesDontWrap(t *testing.T) {
+	TestSuite{
+		SliceCollectTest("RangeBy(250,255,10)uint8", RangeBy[uint8](250, 255, 10), []uint8{250}),
+		SliceCollectTest("RangeBy(250,255,3)uint8", RangeBy[uint8](250, 255, 3), []uint8{250, 253}),
+		SliceCollectTest("RangeBy(5,0,10)uint8", RangeBy[uint8](5, 0, 10), []uint8{5}),
+		SliceCollectTest("RangeBy(120,127,5)int8", RangeBy[int8](120, 127, 5), []int8{120, 125}),
+		SliceCollectTest("RangeBy(-120,-128,5)int8", RangeBy[int8](-120, -128, 5), []int8{-120, -125}),
+		SliceCollectTest("Range(250,255)uint8", Range[uint8](250, 255), []uint8{250, 251, 252, 253, 254}),
+	}.Run(t)
+}
+
+func TestRangesInclusive(t *testing.T) {
+	TestSuite{
+		SliceCollectTest("RangeInclusive(0,3)", RangeInclusive(0, 3), []int{0, 1, 2, 3}),
+		SliceCollectTest("RangeInclusive(3,0)", RangeInclusive(3, 0), []int{3, 2, 1, 0}),
+		SliceCollectTest("RangeInclusive(3,3)", RangeInclusive(3, 3), []int{3}),
+		SliceCollectTest("RangeByInclusive(0,10,5)", RangeByInclusive(0, 10, 5), []int{0, 5, 10}),
+		SliceCollectTest("RangeByInclusive(0,10,3)", RangeByInclusive(0, 10, 3), []int{0, 3, 6, 9}),
+		SliceCollectTest("RangeInclusive(253,255)uint8", RangeInclusive[uint8](253, 255), []uint8{253, 254, 255}),
+		SliceCollectTest("RangeInclusive(-126,-128)int8", RangeInclusive[int8](-126, -128), []int8{-126, -127, -128}),
+		SliceCollectTest("RangeByInclusive(0,1,0.25)float64", RangeByInclusive(0, 1, 0.25), []float64{0, 0.25, 0.5, 0.75, 1}),
+	}.Run(t)
+}
+
+func TestRangeFloatDrift(t *testing.T) {
+	// Repeatedly adding 0.1 ten times gives 0.9999999999999999, so end would
+	// be missed if the values were accumulated.
+	got := slices.Collect(RangeByInclusive(0, 1, 0.1))
+	assert.Len(t, got, 11, "end reached after ten steps")
+	for i, v := range got {
+		assert.Equal(t, float64(i)*0.1, v, "value computed as start + n*step")
+	}
+	assert.Equal(t, 1.0, got[10], "end is exact")
+
+	gotDown := slices.Collect(RangeBy(1, 0, 0.1))
+	assert.Len(t, gotDown, 10, "counts down to just above end")
+	assert.InDelta(t, 0.1, gotDown[9], 1e-15)
+}
+
+func TestCountChecked(t *testing.T) {
+	TestSuite{
+		SliceCollectTest("CountUpChecked(250,2)uint8", CountUpChecked[uint8](250, 2), []uint8{250, 252, 254}),
+		SliceCollectTest("CountUpChecked(125,1)int8", CountUpChecked[int8](125, 1), []int8{125, 126, 127}),
+		SliceCollectTest("CountDownChecked(2,1)uint8", CountDownChecked[uint8](2, 1), []uint8{2, 1, 0}),
+		SliceCollectTest("CountDownChecked(-126,1)int8", CountDownChecked[int8](-126, 1), []int8{-126, -127, -128}),
+		SliceCollectTest("Limit(CountUpChecked(0,1),3)", Limit(CountUpChecked(0, 1), 3), []int{0, 1, 2}),
+		SliceCollectTest("CountUpChecked(MaxFloat64)", CountUpChecked(math.MaxFloat64, math.MaxFloat64), []float64{math.MaxFloat64, math.Inf(1)}),
+	}.Run(t)
+}
+
+func TestLinspace(t *testing.T) {
+	TestSuite{
+		SliceCollectTest("Linspace(0,1,5)", Linspace(0.0, 1, 5), []float64{0, 0.25, 0.5, 0.75, 1}),
+		SliceCollectTest("Linspace(1,0,3)", Linspace(1.0, 0, 3), []float64{1, 0.5, 0}),
+		SliceCollectTest("Linspace(2,5,1)", Linspace(2.0, 5, 1), []float64{2}),
+		SliceCollectTest("Linspace(2,5,0)", Linspace(2.0, 5, 0), []float64{}),
+		SliceCollectTest("Linspace(0,0.3,4)float32", Linspace[float32](0, 0.3, 4), []float32{0, 0.1, 0.2, 0.3}),
+		SliceCollectTest("Limit(Linspace(0,10,11),2)", Limit(Linspace(0.0, 10, 11), 2), []float64{0, 1}),
+	}.Run(t)
+}
+
 func TestCountPanics(t *testing.T) {
 	t.Run("Count", func(t *testing.T) {
 		PanicTestCases(func(it iter.Seq[int]) iter.Seq[int] {
@@ -0,0 +1,292 @@
+package xiter
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"strconv"
+)
+
+// CSVRecords returns a SeqErr that yields each record read from r by a
+// csv.Reader. Each record is newly allocated, and may be retained by the
+// caller. A read or parse error is yielded as the final element. As with
+// Lines, iterating a second time continues from where the first stopped.
+func CSVRecords(r io.Reader) iter.Seq2[[]string, error] {
+	cr := csv.NewReader(r)
+	return func(yield func([]string, error) bool) {
+		for {
+			record, err := cr.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(record, nil) {
+				return
+			}
+		}
+	}
+}
+
+// CSVRows returns a SeqErr that reads CSV data from r, and yields each record
+// as a value of the struct type T. The first record is a header, which is
+// matched against the exported fields of T by the name in their `csv` struct
+// tag, or by the field name if there is no tag. Fields tagged with "-" are
+// ignored, as are columns with no matching field, and fields with no matching
+// column are left as their zero value. Fields promoted from embedded structs
+// are included, with embedded struct pointers allocated as needed, except for
+// those promoted through an unexported embedded pointer, which can't be set.
+//
+// Fields may be strings, booleans, integers, floating point numbers, or types
+// implementing encoding.TextUnmarshaler. A read, parse or conversion error is
+// yielded as the final element.
+func CSVRows[T any](r io.Reader) iter.Seq2[T, error] {
+	fields, fieldsErr := csvFields(reflect.TypeFor[T](), false)
+	records := CSVRecords(r)
+	// columns holds the field for each column, or nil if it's unused. It is
+	// kept, along with the row number, so a second iteration can continue.
+	var columns []*csvField
+	row := 0
+	return func(yield func(T, error) bool) {
+		var zero T
+		if fieldsErr != nil {
+			yield(zero, fieldsErr)
+			return
+		}
+
+		for record, err := range records {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if row++; columns == nil {
+				columns = make([]*csvField, len(record))
+				for i, name := range record {
+					for j := range fields {
+						if fields[j].name == name {
+							columns[i] = &fields[j]
+						}
+					}
+				}
+				continue
+			}
+
+			var t T
+			v := reflect.ValueOf(&t).Elem()
+			for i, f := range columns {
+				if f == nil {
+					continue
+				}
+				if err := f.parse(fieldByIndexAlloc(v, f.index), record[i]); err != nil {
+					yield(zero, fmt.Errorf("record %d, column %q: %w", row, f.name, err))
+					return
+				}
+			}
+			if !yield(t, nil) {
+				return
+			}
+		}
+	}
+}
+
+// WriteCSV consumes the input iterator, writing each element to w as a CSV
+// record. A header record is written first, using the same field names as
+// CSVRows, so the output can be read back by it. Fields must be of the types
+// supported by CSVRows, with encoding.TextMarshaler used in place of
+// encoding.TextUnmarshaler, and unsupported fields are reported before anything
+// is written. Fields promoted through a nil embedded struct pointer are written
+// as empty strings. WriteCSV stops at the first error, and returns it.
+func WriteCSV[T any](w io.Writer, it iter.Seq[T]) error {
+	fields, err := csvFields(reflect.TypeFor[T](), true)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	record := make([]string, len(fields))
+	for i, f := range fields {
+		record[i] = f.name
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	for i, t := range Enumerate(it) {
+		// Addressable, so that pointer receiver MarshalText methods can be used.
+		v := reflect.ValueOf(&t).Elem()
+		for j, f := range fields {
+			fv, err := v.FieldByIndexErr(f.index)
+			if err != nil {
+				// A nil embedded struct pointer.
+				record[j] = ""
+				continue
+			}
+			if record[j], err = f.format(fv); err != nil {
+				return fmt.Errorf("element %d, field %q: %w", i, f.name, err)
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvField describes a struct field that is mapped to a CSV column.
+type csvField struct {
+	name  string
+	index []int
+	typ   reflect.Type
+}
+
+var (
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// csvFields returns the fields of the struct type t that are mapped to CSV
+// columns, or an error if t isn't a struct, or has a field of a type that
+// can't be written, or read if write is false.
+func csvFields(t reflect.Type, write bool) ([]csvField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csv: %s is not a struct type", t)
+	}
+	var fields []csvField
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || sf.Anonymous || !settablePath(t, sf.Index) {
+			continue
+		}
+		name := sf.Tag.Get("csv")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		f := csvField{name: name, index: sf.Index, typ: sf.Type}
+		if !f.supported(write) {
+			return nil, fmt.Errorf("csv: field %s has unsupported type %s", sf.Name, sf.Type)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// settablePath reports whether the field of the struct type t with the given
+// index sequence is not promoted through an unexported embedded pointer, which
+// reflect can't allocate.
+func settablePath(t reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		sf := t.Field(i)
+		t = sf.Type
+		if t.Kind() == reflect.Pointer {
+			if !sf.IsExported() {
+				return false
+			}
+			t = t.Elem()
+		}
+	}
+	return true
+}
+
+// fieldByIndexAlloc is like v.FieldByIndex, but allocates any nil embedded
+// struct pointers along the way.
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// supported reports whether the field's type can be converted to text, if
+// write is true, or from text otherwise.
+func (f *csvField) supported(write bool) bool {
+	if write && (f.typ.Implements(textMarshalerType) || reflect.PointerTo(f.typ).Implements(textMarshalerType)) {
+		return true
+	}
+	if !write && reflect.PointerTo(f.typ).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch f.typ.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// parse converts the text s to the field's type, and stores it in v.
+func (f *csvField) parse(v reflect.Value, s string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	switch f.typ.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, f.typ.Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, f.typ.Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		x, err := strconv.ParseFloat(s, f.typ.Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(x)
+	}
+	return nil
+}
+
+// format converts the value v of the field's type to text. The value must be
+// addressable if the type's MarshalText method has a pointer receiver.
+func (f *csvField) format(v reflect.Value) (string, error) {
+	m, ok := v.Interface().(encoding.TextMarshaler)
+	if !ok && v.CanAddr() {
+		m, ok = v.Addr().Interface().(encoding.TextMarshaler)
+	}
+	if ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+	switch f.typ.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, f.typ.Bits()), nil
+	}
+	return "", fmt.Errorf("unsupported type %s", f.typ)
+}
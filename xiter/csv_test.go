@@ -0,0 +1,174 @@
+package xiter
+
+import (
+	"bytes"
+	"io"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type csvAudit struct {
+	When time.Time `csv:"when"`
+}
+
+type csvPerson struct {
+	Name    string  `csv:"name"`
+	Age     uint8   `csv:"age"`
+	Score   float64 `csv:"score"`
+	Active  bool    `csv:"active"`
+	Ignored string  `csv:"-"`
+	Balance int
+	csvAudit
+	hidden int
+}
+
+func TestCSVRecords(t *testing.T) {
+	TestSuite{
+		collectErrTestCase("empty", CSVRecords(strings.NewReader("")), nil, nil),
+		collectErrTestCase("records", CSVRecords(strings.NewReader("a,b\n1,\"2,3\"\n")), list(list("a", "b"), list("1", "2,3")), nil),
+		collectErrTestCase("readError", CSVRecords(io.MultiReader(strings.NewReader("a,b\n"), iotest.ErrReader(errTest))), list(list("a", "b")), errTest),
+	}.Run(t)
+
+	_, err := CollectErr(CSVRecords(strings.NewReader("a,b\n1\n")))
+	assert.ErrorContains(t, err, "wrong number of fields")
+}
+
+func TestCSVRows(t *testing.T) {
+	when := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	src := "name,age,score,active,Balance,when,extra\n" +
+		"ann,30,1.5,true,-10,2024-01-02T03:04:05Z,x\n" +
+		"bob,41,2,false,0,2024-01-02T03:04:05Z,y\n"
+	got, err := CollectErr(CSVRows[csvPerson](strings.NewReader(src)))
+	require.NoError(t, err)
+	assert.Equal(t, list(
+		csvPerson{Name: "ann", Age: 30, Score: 1.5, Active: true, Balance: -10, csvAudit: csvAudit{when}},
+		csvPerson{Name: "bob", Age: 41, Score: 2, Balance: 0, csvAudit: csvAudit{when}},
+	), got)
+
+	got, err = CollectErr(CSVRows[csvPerson](strings.NewReader("age,name,Ignored\n7,cat,no\n")))
+	require.NoError(t, err)
+	assert.Equal(t, list(csvPerson{Name: "cat", Age: 7}), got, "columns in any order, missing columns left as zero")
+
+	got, err = CollectErr(CSVRows[csvPerson](strings.NewReader("name,age\nann,30\nbob,300\ncat,3\n")))
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	assert.ErrorContains(t, err, `record 3, column "age"`)
+	assert.Equal(t, list(csvPerson{Name: "ann", Age: 30}), got, "rows before the error")
+
+	_, err = CollectErr(CSVRows[csvPerson](strings.NewReader("when\nyesterday\n")))
+	assert.ErrorContains(t, err, `column "when"`)
+
+	_, err = CollectErr(CSVRows[int](strings.NewReader("a\n1\n")))
+	assert.ErrorContains(t, err, "not a struct type")
+
+	_, err = CollectErr(CSVRows[struct{ Tags []string }](strings.NewReader("Tags\na\n")))
+	assert.ErrorContains(t, err, "unsupported type")
+}
+
+func TestWriteCSV(t *testing.T) {
+	when := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	people := list(
+		csvPerson{Name: "ann", Age: 30, Score: 1.5, Active: true, Ignored: "x", Balance: -10, csvAudit: csvAudit{when}},
+		csvPerson{Name: "bob, jr", Age: 41, Score: 0.1, hidden: 5},
+	)
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, slices.Values(people)))
+	assert.Equal(t, "name,age,score,active,Balance,when\n"+
+		"ann,30,1.5,true,-10,2024-01-02T03:04:05Z\n"+
+		"\"bob, jr\",41,0.1,false,0,0001-01-01T00:00:00Z\n", buf.String())
+
+	got, err := CollectErr(CSVRows[csvPerson](&buf))
+	require.NoError(t, err)
+	people[0].Ignored, people[1].hidden = "", 0
+	assert.Equal(t, people, got, "round trip")
+
+	assert.ErrorContains(t, WriteCSV(io.Discard, slices.Values(list(1, 2))), "not a struct type")
+
+	PanicTestCases(func(s iter.Seq[csvPerson]) iter.Seq[csvPerson] {
+		_ = WriteCSV(io.Discard, s)
+		return s
+	}).Run(t)
+}
+
+// csvLevel has pointer receiver text methods.
+type csvLevel struct{ n int }
+
+func (l *csvLevel) MarshalText() ([]byte, error) { return []byte(strings.Repeat("*", l.n)), nil }
+
+func (l *csvLevel) UnmarshalText(b []byte) error {
+	l.n = len(b)
+	return nil
+}
+
+// csvReadOnly can only be read from text.
+type csvReadOnly struct{ s string }
+
+func (r *csvReadOnly) UnmarshalText(b []byte) error {
+	r.s = string(b)
+	return nil
+}
+
+type CSVPlace struct {
+	City string `csv:"city"`
+}
+
+type csvHidden struct {
+	Secret string
+}
+
+type csvVisit struct {
+	Name  string   `csv:"name"`
+	Level csvLevel `csv:"level"`
+	*CSVPlace
+	*csvHidden
+}
+
+func TestCSVEmbeddedPointers(t *testing.T) {
+	visits := list(
+		csvVisit{Name: "ann", Level: csvLevel{2}, CSVPlace: &CSVPlace{"Oslo"}},
+		csvVisit{Name: "bob", csvHidden: &csvHidden{"x"}},
+	)
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, slices.Values(visits)))
+	assert.Equal(t, "name,level,city\nann,**,Oslo\nbob,,\n", buf.String(),
+		"nil embedded pointer written as empty, unexported embedded pointer skipped")
+
+	got, err := CollectErr(CSVRows[csvVisit](&buf))
+	require.NoError(t, err)
+	assert.Equal(t, list(
+		csvVisit{Name: "ann", Level: csvLevel{2}, CSVPlace: &CSVPlace{"Oslo"}},
+		csvVisit{Name: "bob", CSVPlace: &CSVPlace{}},
+	), got, "embedded pointers allocated")
+}
+
+func TestWriteCSVUnsupported(t *testing.T) {
+	type row struct {
+		Name string
+		Note csvReadOnly
+	}
+	_, err := CollectErr(CSVRows[row](strings.NewReader("Name,Note\na,b\n")))
+	assert.NoError(t, err, "readable")
+
+	var buf bytes.Buffer
+	assert.ErrorContains(t, WriteCSV(&buf, slices.Values(list(row{Name: "a"}))), "unsupported type")
+	assert.Empty(t, buf.String(), "nothing written")
+}
+
+func TestCSVRowsResumes(t *testing.T) {
+	rows := CSVRows[csvPerson](strings.NewReader("name,age\nann,30\nbob,41\ncat,7\n"))
+	for p, err := range rows {
+		assert.NoError(t, err)
+		assert.Equal(t, "ann", p.Name)
+		break
+	}
+	rest, err := CollectErr(rows)
+	assert.NoError(t, err)
+	assert.Equal(t, list(csvPerson{Name: "bob", Age: 41}, csvPerson{Name: "cat", Age: 7}), rest, "header kept for the second iteration")
+}
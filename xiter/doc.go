@@ -1,4 +1,11 @@
 // Package xiter provides functions that create iterators from common cases,
 // process an iterator to produce a new one, or consume an iterator in a
 // common or useful way.
+//
+// An iterator over values that may fail is an iter.Seq2[T, error], referred
+// to in this package as a SeqErr. Each step yields either a value with a nil
+// error, or a zero value with a non-nil error. By convention, an error is the
+// last element yielded, and functions in this package that process a SeqErr
+// stop at the first error. As a SeqErr is a plain iter.Seq2, it can be used
+// directly with functions such as Filter2 and Limit2.
 package xiter
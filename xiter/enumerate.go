@@ -0,0 +1,23 @@
+package xiter
+
+import "iter"
+
+// Enumerate returns an iterator that yields each element of the input iterator
+// paired with its position, starting from 0.
+func Enumerate[T any](it iter.Seq[T]) iter.Seq2[int, T] {
+	return EnumerateFrom(it, 0)
+}
+
+// EnumerateFrom returns an iterator that yields each element of the input
+// iterator paired with its position, starting from the given start value.
+func EnumerateFrom[T any](it iter.Seq[T], start int) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := start
+		for t := range it {
+			if !yield(i, t) {
+				return
+			}
+			i++
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package xiter
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestEnumerate(t *testing.T) {
+	words := list("a", "b", "c")
+	TestSuite{
+		SliceCollectTest2("nil", Enumerate(slices.Values([]string(nil))), nil),
+		SliceCollectTest2("words", Enumerate(slices.Values(words)), pairUp(list(0, 1, 2), words)),
+		SliceCollectTest2("from", EnumerateFrom(slices.Values(words), 10), pairUp(list(10, 11, 12), words)),
+		SliceCollectTest2("negative", EnumerateFrom(slices.Values(words), -1), pairUp(list(-1, 0, 1), words)),
+		SliceCollectTest2("infinite", Limit2(Enumerate(Forever("x")), 2), pairUp(list(0, 1), list("x", "x"))),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			keys, _ := Unzip(Enumerate(s))
+			return keys
+		}),
+	}.Run(t)
+}
@@ -19,3 +19,21 @@ func Exclude[T any](it iter.Seq[T], f func(T) bool) iter.Seq[T] {
 		return f(t) || yield(t)
 	})
 }
+
+// Filter2 applies the given predicate function f to each pair of elements in
+// the input iterator it, and returns a new iterator that yields only the pairs
+// for which f returns true.
+func Filter2[K, V any](it iter.Seq2[K, V], f func(K, V) bool) iter.Seq2[K, V] {
+	return Process2(it, func(k K, v V, yield func(K, V) bool) bool {
+		return !f(k, v) || yield(k, v)
+	})
+}
+
+// Exclude2 applies the given predicate function f to each pair of elements in
+// the input iterator it, and returns a new iterator that yields only the pairs
+// for which f returns false.
+func Exclude2[K, V any](it iter.Seq2[K, V], f func(K, V) bool) iter.Seq2[K, V] {
+	return Process2(it, func(k K, v V, yield func(K, V) bool) bool {
+		return f(k, v) || yield(k, v)
+	})
+}
@@ -51,3 +51,33 @@ func TestFilterExclude(t *testing.T) {
 		}.Run(t)
 	})
 }
+
+func TestFilterExclude2(t *testing.T) {
+	nums := []int{10, 11, 12, 13}
+	evenIndex := func(i, _ int) bool { return i%2 == 0 }
+	all := func(int, int) bool { return true }
+
+	t.Run("Filter2", func(t *testing.T) {
+		TestSuite{
+			SliceCollectTest2("all", Filter2(slices.All(nums), all), pairUp(list(0, 1, 2, 3), nums)),
+			SliceCollectTest2("allNil", Filter2(slices.All([]int(nil)), all), nil),
+			SliceCollectTest2("evenIndex", Filter2(slices.All(nums), evenIndex), pairUp(list(0, 2), list(10, 12))),
+
+			PanicTestCases2(func(s iter.Seq2[int, int]) iter.Seq2[int, int] {
+				return Filter2(s, all)
+			}),
+		}.Run(t)
+	})
+
+	t.Run("Exclude2", func(t *testing.T) {
+		TestSuite{
+			SliceCollectTest2("all", Exclude2(slices.All(nums), all), nil),
+			SliceCollectTest2("allNil", Exclude2(slices.All([]int(nil)), all), nil),
+			SliceCollectTest2("evenIndex", Exclude2(slices.All(nums), evenIndex), pairUp(list(1, 3), list(11, 13))),
+
+			PanicTestCases2(func(s iter.Seq2[int, int]) iter.Seq2[int, int] {
+				return Exclude2(s, func(int, int) bool { return false })
+			}),
+		}.Run(t)
+	})
+}
@@ -0,0 +1,38 @@
+package xiter
+
+import (
+	"iter"
+	"slices"
+)
+
+// Concat returns an iterator that yields all the elements of each of the
+// given iterators in turn. Iteration stops without touching the remaining
+// iterators if the consumer stops early.
+func Concat[T any](its ...iter.Seq[T]) iter.Seq[T] {
+	return Flatten(slices.Values(its))
+}
+
+// Flatten returns an iterator that yields all the elements of each iterator
+// produced by the input iterator, in order.
+func Flatten[T any](it iter.Seq[iter.Seq[T]]) iter.Seq[T] {
+	return FlatMap(it, func(inner iter.Seq[T]) iter.Seq[T] { return inner })
+}
+
+// FlattenSlices returns an iterator that yields all the elements of each slice
+// produced by the input iterator, in order.
+func FlattenSlices[T any](it iter.Seq[[]T]) iter.Seq[T] {
+	return FlatMap(it, slices.Values[[]T])
+}
+
+// FlatMap applies the given function f to each element of the input iterator,
+// and yields all the elements of each resulting iterator, in order.
+func FlatMap[T, U any](it iter.Seq[T], f func(T) iter.Seq[U]) iter.Seq[U] {
+	return Process(it, func(t T, yield func(U) bool) bool {
+		for u := range f(t) {
+			if !yield(u) {
+				return false
+			}
+		}
+		return true
+	})
+}
@@ -0,0 +1,71 @@
+package xiter
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcat(t *testing.T) {
+	TestSuite{
+		SliceCollectTest("none", Concat[int](), nil),
+		SliceCollectTest("one", Concat(slices.Values(list(1, 2))), list(1, 2)),
+		SliceCollectTest("many", Concat(slices.Values(list(1, 2)), One(3), slices.Values([]int{}), Range(4, 6)), list(1, 2, 3, 4, 5)),
+		SliceCollectTest("infinite", Limit(Concat(One(0), Count(10)), 3), list(0, 10, 11)),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			return Concat(One(1), s)
+		}),
+	}.Run(t)
+}
+
+func TestConcatStops(t *testing.T) {
+	a, aStopped := stopTracker(1, 2)
+	b, bStopped := stopTracker(3, 4)
+	for v := range Concat(a, b) {
+		if v == 2 {
+			break
+		}
+	}
+	assert.True(t, *aStopped, "first source stopped")
+	assert.False(t, *bStopped, "second source never started")
+}
+
+func TestFlatten(t *testing.T) {
+	TestSuite{
+		SliceCollectTest("empty", Flatten(slices.Values([]iter.Seq[int]{})), nil),
+		SliceCollectTest("some", Flatten(slices.Values(list(One(1), Range(2, 4), Repeat(9, 0)))), list(1, 2, 3)),
+		SliceCollectTest("limited", Limit(Flatten(Map(Count(0), func(i int) iter.Seq[int] { return Repeat(i, 2) })), 5), list(0, 0, 1, 1, 2)),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			return Flatten(Map(s, One))
+		}),
+	}.Run(t)
+}
+
+func TestFlattenSlices(t *testing.T) {
+	TestSuite{
+		SliceCollectTest("empty", FlattenSlices(slices.Values([][]int{})), nil),
+		SliceCollectTest("some", FlattenSlices(slices.Values(list(list(1, 2), nil, list(3)))), list(1, 2, 3)),
+		SliceCollectTest("limited", Limit(FlattenSlices(slices.Values(list(list(1, 2), list(3)))), 2), list(1, 2)),
+
+		PanicTestCases(func(s iter.Seq[[]int]) iter.Seq[int] {
+			return FlattenSlices(s)
+		}),
+	}.Run(t)
+}
+
+func TestFlatMap(t *testing.T) {
+	upTo := func(n int) iter.Seq[int] { return Range(0, n) }
+	TestSuite{
+		SliceCollectTest("empty", FlatMap(slices.Values([]int{}), upTo), nil),
+		SliceCollectTest("some", FlatMap(slices.Values(list(1, 0, 3)), upTo), list(0, 0, 1, 2)),
+		SliceCollectTest("limited", Limit(FlatMap(Count(1), upTo), 4), list(0, 0, 1, 0)),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			return FlatMap(s, upTo)
+		}),
+	}.Run(t)
+}
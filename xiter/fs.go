@@ -0,0 +1,191 @@
+package xiter
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"iter"
+	"path"
+	"strings"
+)
+
+// WalkDir returns an iterator that walks the file tree rooted at root in the
+// file system fsys, yielding the path and fs.DirEntry of each file or
+// directory in the tree, including root, in lexical order. It is a range-func
+// version of fs.WalkDir. Use WalkDirEntries to skip directories during the
+// walk.
+//
+// Stopping the loop early stops the walk. If an error occurs while walking,
+// the iterator panics with it, so that it can be recovered by xerrors.Catch.
+func WalkDir(fsys fs.FS, root string) iter.Seq2[string, fs.DirEntry] {
+	return func(yield func(string, fs.DirEntry) bool) {
+		for p, e := range WalkDirEntries(fsys, root) {
+			if !yield(p, e.DirEntry) {
+				return
+			}
+		}
+	}
+}
+
+// WalkEntry is an fs.DirEntry yielded by WalkDirEntries, which can also be used
+// to control which parts of the file tree are visited.
+type WalkEntry struct {
+	fs.DirEntry
+	skipDir *bool
+}
+
+// SkipDir skips the rest of the current directory. If the entry is a
+// directory, its contents are not visited. Otherwise, the remaining entries in
+// the directory containing it are skipped. This matches the behaviour of
+// returning fs.SkipDir from an fs.WalkDirFunc. SkipDir only has an effect when
+// called from the body of the loop the entry was yielded to, before the next
+// entry.
+func (e WalkEntry) SkipDir() {
+	*e.skipDir = true
+}
+
+// WalkDirEntries is like WalkDir, except that each entry is yielded as a
+// WalkEntry, whose SkipDir method can be called from the body of the loop to
+// skip directories. The skip state belongs to each iteration, so the iterator
+// may be used by several loops at once.
+func WalkDirEntries(fsys fs.FS, root string) iter.Seq2[string, WalkEntry] {
+	return func(yield func(string, WalkEntry) bool) {
+		skipDir := false
+		err := fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			skipDir = false
+			if !yield(p, WalkEntry{d, &skipDir}) {
+				return fs.SkipAll
+			}
+			if skipDir {
+				skipDir = false
+				return fs.SkipDir
+			}
+			return nil
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Glob returns an iterator that yields the names of all the files in fsys
+// matching pattern, in lexical order. The syntax of patterns is the same as in
+// path.Match. Unlike fs.Glob, matches are found as they are needed, so only
+// the directories required to produce the matches consumed are read.
+//
+// As with fs.Glob, file system errors such as I/O errors reading directories
+// are ignored. If the pattern is malformed, the iterator panics with
+// path.ErrBadPattern.
+func Glob(fsys fs.FS, pattern string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			panic(err)
+		}
+		globParts(fsys, ".", strings.Split(pattern, "/"), yield)
+	}
+}
+
+// globParts yields the names of files within dir matching the pattern split
+// into its path elements, returning false if the consumer stops early.
+func globParts(fsys fs.FS, dir string, parts []string, yield func(string) bool) bool {
+	join := func(name string) string {
+		if dir == "." {
+			return name
+		}
+		return dir + "/" + name
+	}
+
+	part, rest := parts[0], parts[1:]
+	if !strings.ContainsAny(part, `*?[\`) {
+		name := join(part)
+		if len(rest) == 0 {
+			if _, err := fs.Stat(fsys, name); err != nil {
+				return true
+			}
+			return yield(name)
+		}
+		return globParts(fsys, name, rest, yield)
+	}
+
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return true
+	}
+	for _, e := range entries {
+		if ok, _ := path.Match(part, e.Name()); !ok {
+			continue
+		}
+		name := join(e.Name())
+		if len(rest) == 0 {
+			if !yield(name) {
+				return false
+			}
+		} else if e.IsDir() && !globParts(fsys, name, rest, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadDirSorted returns a SeqErr that yields the entries of the directory name
+// in fsys, sorted by filename. As with fs.ReadDir, the whole directory is read
+// before the first entry is yielded. An error reading the directory is yielded
+// as the only element.
+func ReadDirSorted(fsys fs.FS, name string) iter.Seq2[fs.DirEntry, error] {
+	return func(yield func(fs.DirEntry, error) bool) {
+		entries, err := fs.ReadDir(fsys, name)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for _, e := range entries {
+			if !yield(e, nil) {
+				return
+			}
+		}
+	}
+}
+
+// readDirBatch is the number of entries ReadDirLazy reads at a time.
+const readDirBatch = 64
+
+// ReadDirLazy returns a SeqErr that yields the entries of the directory name
+// in fsys, in the order they are returned by the file system. Entries are read
+// in small batches as needed, so large directories can be processed without
+// holding all their entries in memory. The directory is closed once iteration
+// finishes, including when the consumer stops early. An error opening or
+// reading the directory is yielded as the final element.
+func ReadDirLazy(fsys fs.FS, name string) iter.Seq2[fs.DirEntry, error] {
+	return func(yield func(fs.DirEntry, error) bool) {
+		f, err := fsys.Open(name)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer f.Close()
+
+		dir, ok := f.(fs.ReadDirFile)
+		if !ok {
+			yield(nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not implemented")})
+			return
+		}
+		for {
+			entries, err := dir.ReadDir(readDirBatch)
+			for _, e := range entries {
+				if !yield(e, nil) {
+					return
+				}
+			}
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
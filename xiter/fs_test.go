@@ -0,0 +1,157 @@
+package xiter
+
+import (
+	"io/fs"
+	"iter"
+	"path"
+	"slices"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cookieo9/go-std-addons/xerrors"
+)
+
+var testFS = fstest.MapFS{
+	"a.txt":            {Data: []byte("a")},
+	"b.go":             {Data: []byte("b")},
+	"dir/c.txt":        {Data: []byte("c")},
+	"dir/d.go":         {Data: []byte("d")},
+	"dir/sub/e.txt":    {Data: []byte("e")},
+	"other/f.txt":      {Data: []byte("f")},
+	"other/skip/g.txt": {Data: []byte("g")},
+}
+
+func TestWalkDir(t *testing.T) {
+	paths, _ := Unzip(WalkDir(testFS, "."))
+	TestSuite{
+		SliceCollectTest("all", paths, list(".", "a.txt", "b.go", "dir", "dir/c.txt", "dir/d.go", "dir/sub", "dir/sub/e.txt",
+			"other", "other/f.txt", "other/skip", "other/skip/g.txt")),
+		SliceCollectTest("limited", Limit(paths, 3), list(".", "a.txt", "b.go")),
+	}.Run(t)
+
+	subDirs := Filter2(WalkDir(testFS, "dir/sub"), func(_ string, d fs.DirEntry) bool { return d.IsDir() })
+	k, d, ok := First2(subDirs)
+	assert.True(t, ok)
+	assert.Equal(t, "dir/sub", k)
+	assert.Equal(t, "sub", d.Name())
+
+	_, err := xerrors.CatchValue(func() []string {
+		keys, _ := Unzip(WalkDir(testFS, "missing"))
+		return slices.Collect(keys)
+	})
+	assert.ErrorIs(t, err, fs.ErrNotExist, "error reported by panic")
+}
+
+func TestWalkDirSkipDir(t *testing.T) {
+	walk := WalkDirEntries(testFS, ".")
+	var got []string
+	for p, e := range walk {
+		got = append(got, p)
+		switch {
+		case e.IsDir() && path.Base(p) == "skip", p == "dir/c.txt":
+			e.SkipDir()
+		}
+	}
+	assert.Equal(t, list(".", "a.txt", "b.go", "dir", "dir/c.txt", "other", "other/f.txt", "other/skip"), got)
+}
+
+func TestWalkDirEntriesIndependent(t *testing.T) {
+	walk := WalkDirEntries(testFS, ".")
+	all := list(".", "a.txt", "b.go", "dir", "dir/c.txt", "dir/d.go", "dir/sub", "dir/sub/e.txt",
+		"other", "other/f.txt", "other/skip", "other/skip/g.txt")
+
+	// An outer walk that skips every directory, with a full walk nested in the
+	// first step, which must not be affected.
+	var outer, inner []string
+	for p, e := range walk {
+		outer = append(outer, p)
+		if p == "." {
+			for q := range walk {
+				inner = append(inner, q)
+			}
+			continue
+		}
+		if e.IsDir() {
+			e.SkipDir()
+		}
+	}
+	assert.Equal(t, all, inner, "nested walk unaffected")
+	assert.Equal(t, list(".", "a.txt", "b.go", "dir", "other"), outer)
+
+	var wg sync.WaitGroup
+	results := make([][]string, 4)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p, e := range walk {
+				results[i] = append(results[i], p)
+				if i%2 == 0 && e.IsDir() && p != "." {
+					e.SkipDir()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	for i, got := range results {
+		if i%2 == 0 {
+			assert.Equal(t, list(".", "a.txt", "b.go", "dir", "other"), got, "concurrent skipping walk %d", i)
+		} else {
+			assert.Equal(t, all, got, "concurrent full walk %d", i)
+		}
+	}
+}
+
+func TestGlob(t *testing.T) {
+	TestSuite{
+		SliceCollectTest("topLevel", Glob(testFS, "*.txt"), list("a.txt")),
+		SliceCollectTest("nested", Glob(testFS, "*/*.txt"), list("dir/c.txt", "other/f.txt")),
+		SliceCollectTest("literalDir", Glob(testFS, "dir/*"), list("dir/c.txt", "dir/d.go", "dir/sub")),
+		SliceCollectTest("deep", Glob(testFS, "*/*/*.txt"), list("dir/sub/e.txt", "other/skip/g.txt")),
+		SliceCollectTest("literal", Glob(testFS, "dir/d.go"), list("dir/d.go")),
+		SliceCollectTest("missing", Glob(testFS, "missing/*"), nil),
+		SliceCollectTest("class", Glob(testFS, "[ab].*"), list("a.txt", "b.go")),
+		SliceCollectTest("limited", Limit(Glob(testFS, "*/*"), 2), list("dir/c.txt", "dir/d.go")),
+		SliceCollectTest("badPattern", Glob(testFS, "[a"), nil).PanicsError(path.ErrBadPattern),
+	}.Run(t)
+
+	for _, pattern := range list("*", "*/*", "*/*.go", "dir/*/*", "?.*") {
+		want, err := fs.Glob(testFS, pattern)
+		require.NoError(t, err)
+		assert.Equal(t, want, slices.Collect(Glob(testFS, pattern)), "matches fs.Glob(%q)", pattern)
+	}
+}
+
+func dirNames(s iter.Seq2[fs.DirEntry, error]) iter.Seq[string] {
+	return Map(Must(s), fs.DirEntry.Name)
+}
+
+func TestReadDir(t *testing.T) {
+	TestSuite{
+		SliceCollectTest("sorted", dirNames(ReadDirSorted(testFS, "dir")), list("c.txt", "d.go", "sub")),
+		SliceCollectTest("sortedMissing", dirNames(ReadDirSorted(testFS, "missing")), nil).Panics(),
+		SliceCollectTest("lazyMissing", dirNames(ReadDirLazy(testFS, "missing")), nil).Panics(),
+		SliceCollectTest("lazyLimited", Limit(dirNames(ReadDirLazy(testFS, ".")), 1), list("a.txt")),
+	}.Run(t)
+
+	got := slices.Sorted(dirNames(ReadDirLazy(testFS, ".")))
+	assert.Equal(t, list("a.txt", "b.go", "dir", "other"), got)
+
+	big := fstest.MapFS{}
+	for i := range 200 {
+		big[string(rune('a'+i%26))+string(rune('a'+i/26))] = &fstest.MapFile{}
+	}
+	n := 0
+	for _, err := range ReadDirLazy(big, ".") {
+		require.NoError(t, err)
+		n++
+	}
+	assert.Equal(t, 200, n, "read in several batches")
+
+	_, err := CollectErr(ReadDirLazy(testFS, "a.txt"))
+	assert.Error(t, err, "not a directory")
+}
@@ -0,0 +1,67 @@
+package xiter
+
+import (
+	"iter"
+
+	"github.com/cookieo9/go-std-addons/pair"
+)
+
+// GroupBy consumes the input iterator, and returns a map of the elements
+// grouped by the key produced by the function key. The elements in each group
+// retain the order they were produced by the iterator.
+func GroupBy[T any, K comparable](it iter.Seq[T], key func(T) K) map[K][]T {
+	return Collect(it, make(map[K][]T), func(groups map[K][]T, t T) map[K][]T {
+		k := key(t)
+		groups[k] = append(groups[k], t)
+		return groups
+	})
+}
+
+// Partition consumes the input iterator, and returns two slices: the elements
+// for which the predicate function pred returns true, and those for which it
+// returns false. The elements in each slice retain their original order.
+func Partition[T any](it iter.Seq[T], pred func(T) bool) (yes, no []T) {
+	parts := Collect(it, [2][]T{}, func(parts [2][]T, t T) [2][]T {
+		if pred(t) {
+			parts[0] = append(parts[0], t)
+		} else {
+			parts[1] = append(parts[1], t)
+		}
+		return parts
+	})
+	return parts[0], parts[1]
+}
+
+// CountBy consumes the input iterator, and returns a map of the number of
+// elements sharing each key produced by the function key.
+func CountBy[T any, K comparable](it iter.Seq[T], key func(T) K) map[K]int {
+	return Collect(it, make(map[K]int), func(counts map[K]int, t T) map[K]int {
+		counts[key(t)]++
+		return counts
+	})
+}
+
+// GroupAdjacent returns an iterator that yields runs of consecutive elements
+// sharing the same key, paired with that key. Unlike GroupBy, only the current
+// run is held in memory, so it is suited to large or indefinite iterators whose
+// elements are already sorted by key. A key that appears in separate runs will
+// be yielded once for each run.
+func GroupAdjacent[T any, K comparable](it iter.Seq[T], key func(T) K) iter.Seq[pair.Pair[K, []T]] {
+	return func(yield func(pair.Pair[K, []T]) bool) {
+		var current pair.Pair[K, []T]
+		for t := range it {
+			k := key(t)
+			if len(current.B) > 0 && k != current.A {
+				if !yield(current) {
+					return
+				}
+				current.B = nil
+			}
+			current.A = k
+			current.B = append(current.B, t)
+		}
+		if len(current.B) > 0 {
+			yield(current)
+		}
+	}
+}
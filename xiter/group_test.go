@@ -0,0 +1,101 @@
+package xiter
+
+import (
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cookieo9/go-std-addons/pair"
+)
+
+func TestGroupBy(t *testing.T) {
+	words := list("apple", "avocado", "banana", "cherry", "blueberry")
+	initial := func(s string) byte { return s[0] }
+	TestSuite{
+		SimpleTest("empty", func(t *testing.T) map[byte][]string {
+			return GroupBy(slices.Values([]string{}), initial)
+		}).Value(assert.Empty),
+		SimpleTest("words", func(t *testing.T) map[byte][]string {
+			return GroupBy(slices.Values(words), initial)
+		}).Compare(map[byte][]string{
+			'a': list("apple", "avocado"),
+			'b': list("banana", "blueberry"),
+			'c': list("cherry"),
+		}, assert.Equal),
+
+		PanicTestCases(func(s iter.Seq[string]) iter.Seq[string] {
+			GroupBy(s, initial)
+			return s
+		}),
+	}.Run(t)
+}
+
+type partitionResult[T any] struct {
+	Yes, No []T
+}
+
+func TestPartition(t *testing.T) {
+	isEven := func(i int) bool { return i%2 == 0 }
+	partitionTestCase := func(name string, src []int, want partitionResult[int]) TestCase {
+		return SimpleTest(name, func(t *testing.T) partitionResult[int] {
+			yes, no := Partition(slices.Values(src), isEven)
+			return partitionResult[int]{yes, no}
+		}).Compare(want, assert.Equal)
+	}
+	TestSuite{
+		partitionTestCase("empty", nil, partitionResult[int]{}),
+		partitionTestCase("mixed", list(1, 2, 3, 4, 5), partitionResult[int]{list(2, 4), list(1, 3, 5)}),
+		partitionTestCase("allYes", list(2, 4), partitionResult[int]{list(2, 4), nil}),
+		partitionTestCase("allNo", list(1, 3), partitionResult[int]{nil, list(1, 3)}),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			Partition(s, isEven)
+			return s
+		}),
+	}.Run(t)
+}
+
+func TestCountBy(t *testing.T) {
+	TestSuite{
+		SimpleTest("empty", func(t *testing.T) map[int]int {
+			return CountBy(slices.Values([]string{}), func(s string) int { return len(s) })
+		}).Value(assert.Empty),
+		SimpleTest("lengths", func(t *testing.T) map[int]int {
+			return CountBy(slices.Values(list("a", "bb", "cc", "d", "eee")), func(s string) int { return len(s) })
+		}).Compare(map[int]int{1: 2, 2: 2, 3: 1}, assert.Equal),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			CountBy(s, func(i int) int { return i })
+			return s
+		}),
+	}.Run(t)
+}
+
+func TestGroupAdjacent(t *testing.T) {
+	initial := func(s string) string { return s[:1] }
+	words := list("apple", "avocado", "banana", "blueberry", "cherry", "apricot")
+	TestSuite{
+		SliceCollectTest("empty", GroupAdjacent(slices.Values([]string{}), initial), nil),
+		SliceCollectTest("words", GroupAdjacent(slices.Values(words), initial), list(
+			pair.Of("a", list("apple", "avocado")),
+			pair.Of("b", list("banana", "blueberry")),
+			pair.Of("c", list("cherry")),
+			pair.Of("a", list("apricot")),
+		)),
+		SliceCollectTest("infinite", Limit(GroupAdjacent(Count(0), func(i int) int { return i / 3 }), 2), list(
+			pair.Of(0, list(0, 1, 2)),
+			pair.Of(1, list(3, 4, 5)),
+		)),
+		SliceCollectTest("zeroKey", GroupAdjacent(slices.Values(list("", "", "x")), strings.TrimSpace), list(
+			pair.Of("", list("", "")),
+			pair.Of("x", list("x")),
+		)),
+
+		PanicTestCases(func(s iter.Seq[string]) iter.Seq[pair.Pair[string, []string]] {
+			return GroupAdjacent(s, initial)
+		}),
+	}.Run(t)
+}
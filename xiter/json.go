@@ -0,0 +1,164 @@
+package xiter
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+)
+
+// DecodeJSONStream returns a SeqErr that decodes a stream of JSON values of
+// type T from r, choosing between the formats read by DecodeJSONArray and
+// DecodeJSONLines from the input. If the first value in the stream is an
+// array, it is decoded as by DecodeJSONArray, unless T is itself a slice or
+// array type, in which case each array is a value, as by DecodeJSONLines.
+// Types implementing json.Unmarshaler, such as json.RawMessage, are always
+// treated as the elements of an array. Use DecodeJSONArray or DecodeJSONLines
+// when the format is known, or T is an interface type such as any, which can
+// hold an array.
+//
+// A read or decoding error is yielded as the final element. As with Lines, the
+// reader is consumed as the SeqErr is used, so iterating a second time
+// continues from where the first stopped.
+func DecodeJSONStream[T any](r io.Reader) iter.Seq2[T, error] {
+	return decodeJSON[T](r, jsonAuto)
+}
+
+// DecodeJSONArray returns a SeqErr that decodes each element of a JSON array
+// read from r as a value of type T, using the token streaming of json.Decoder
+// so that the whole array is never held in memory. The array must be the only
+// value in the input. A read or decoding error, a missing array, or data
+// following the array, is yielded as the final element. As with Lines,
+// iterating a second time continues from where the first stopped.
+func DecodeJSONArray[T any](r io.Reader) iter.Seq2[T, error] {
+	return decodeJSON[T](r, jsonArray)
+}
+
+// DecodeJSONLines returns a SeqErr that decodes a stream of whitespace
+// separated JSON values of type T read from r, such as NDJSON. A read or
+// decoding error is yielded as the final element. As with Lines, iterating a
+// second time continues from where the first stopped.
+func DecodeJSONLines[T any](r io.Reader) iter.Seq2[T, error] {
+	return decodeJSON[T](r, jsonLines)
+}
+
+// jsonMode is the input format read by decodeJSON.
+type jsonMode int
+
+const (
+	jsonAuto jsonMode = iota
+	jsonArray
+	jsonLines
+)
+
+var jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+// decodeJSON implements the JSON decoders, reading the input in the given
+// format.
+func decodeJSON[T any](r io.Reader, mode jsonMode) iter.Seq2[T, error] {
+	br := bufio.NewReader(r)
+	dec := json.NewDecoder(br)
+	var started, array, done bool
+	return func(yield func(T, error) bool) {
+		var zero T
+		// fail ends the stream with err, unless it's the end of the input.
+		fail := func(err error) {
+			done = true
+			if !errors.Is(err, io.EOF) {
+				yield(zero, err)
+			}
+		}
+		if done {
+			return
+		}
+		if !started {
+			started = true
+			if mode != jsonLines {
+				var err error
+				if array, err = startsWithArray(br); err != nil {
+					if mode == jsonArray && errors.Is(err, io.EOF) {
+						err = io.ErrUnexpectedEOF
+					}
+					fail(err)
+					return
+				}
+			}
+			switch t := reflect.TypeFor[T](); {
+			case mode == jsonArray && !array:
+				fail(errors.New("json: input is not an array"))
+				return
+			case mode == jsonAuto && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) &&
+				!reflect.PointerTo(t).Implements(jsonUnmarshalerType):
+				// Each value is a whole array.
+				array = false
+			}
+			if array {
+				// Skip the opening '[', which was checked above.
+				if _, err := dec.Token(); err != nil {
+					fail(err)
+					return
+				}
+			}
+		}
+
+		for !array || dec.More() {
+			var t T
+			if err := dec.Decode(&t); err != nil {
+				if array && errors.Is(err, io.EOF) {
+					err = io.ErrUnexpectedEOF
+				}
+				fail(err)
+				return
+			}
+			if !yield(t, nil) {
+				return
+			}
+		}
+		// Check for the closing ']', which is the only way out of the loop,
+		// and that nothing follows it.
+		if _, err := dec.Token(); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			fail(err)
+			return
+		}
+		tok, err := dec.Token()
+		if err == nil {
+			err = fmt.Errorf("invalid data %v after top-level array", tok)
+		}
+		fail(err)
+	}
+}
+
+// startsWithArray skips leading whitespace in br, and reports whether the next
+// byte starts a JSON array. The byte is left unread.
+func startsWithArray(br *bufio.Reader) (bool, error) {
+	for {
+		c, err := br.ReadByte()
+		if err != nil {
+			return false, err
+		}
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return c == '[', br.UnreadByte()
+	}
+}
+
+// EncodeJSONLines consumes the input iterator, writing each element to w as a
+// line of JSON, in the NDJSON format. It stops at the first error, and returns
+// it annotated with the position of the element that caused it.
+func EncodeJSONLines[T any](w io.Writer, it iter.Seq[T]) error {
+	enc := json.NewEncoder(w)
+	for i, t := range Enumerate(it) {
+		if err := enc.Encode(t); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package xiter
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"iter"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type jsonRecord struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestDecodeJSONStream(t *testing.T) {
+	decodeTestCase := func(name, src string, want []jsonRecord, wantErr bool) TestCase {
+		return SimpleTest(name, func(t *testing.T) []jsonRecord {
+			got, err := CollectErr(DecodeJSONStream[jsonRecord](strings.NewReader(src)))
+			if wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			return got
+		}).Compare(want, assert.Equal).Args("same records")
+	}
+	a, b := jsonRecord{"a", 1}, jsonRecord{"b", 2}
+	TestSuite{
+		decodeTestCase("empty", "", nil, false),
+		decodeTestCase("whitespace", " \n ", nil, false),
+		decodeTestCase("emptyArray", " [ ] ", nil, false),
+		decodeTestCase("array", `[{"name":"a","count":1}, {"name":"b","count":2}]`, list(a, b), false),
+		decodeTestCase("ndjson", "{\"name\":\"a\",\"count\":1}\n{\"name\":\"b\",\"count\":2}\n", list(a, b), false),
+		decodeTestCase("single", `{"name":"a","count":1}`, list(a), false),
+		decodeTestCase("badElement", `[{"name":"a","count":1}, {"name":2}]`, list(a), true),
+		decodeTestCase("truncatedArray", `[{"name":"a","count":1}`, list(a), true),
+		decodeTestCase("badLine", "{\"name\":\"a\",\"count\":1}\n{oops}\n", list(a), true),
+	}.Run(t)
+
+	got, err := CollectErr(DecodeJSONStream[int](strings.NewReader("[1,2] [3]")))
+	assert.ErrorContains(t, err, "after top-level array", "trailing data rejected")
+	assert.Equal(t, list(1, 2), got)
+
+	_, err = CollectErr(DecodeJSONStream[int](strings.NewReader("[1,2] x")))
+	assert.Error(t, err, "trailing garbage rejected")
+
+	got, err = CollectErr(DecodeJSONStream[int](io.MultiReader(strings.NewReader("[1, 2, "), iotest.ErrReader(errTest))))
+	assert.ErrorIs(t, err, errTest, "read error reported")
+	assert.Equal(t, list(1, 2), got)
+}
+
+func TestDecodeJSONStreamSlices(t *testing.T) {
+	got, err := CollectErr(DecodeJSONStream[[]int](strings.NewReader("[1,2]\n[3]\n")))
+	assert.NoError(t, err)
+	assert.Equal(t, list(list(1, 2), list(3)), got, "NDJSON of arrays")
+
+	got, err = CollectErr(DecodeJSONStream[[]int](strings.NewReader("[1,2]")))
+	assert.NoError(t, err)
+	assert.Equal(t, list(list(1, 2)), got, "single array value")
+
+	arrays, err := CollectErr(DecodeJSONStream[[2]int](strings.NewReader("[1,2] [3,4]")))
+	assert.NoError(t, err)
+	assert.Equal(t, list([2]int{1, 2}, [2]int{3, 4}), arrays)
+}
+
+func TestDecodeJSONStreamRawMessage(t *testing.T) {
+	got, err := CollectErr(DecodeJSONStream[json.RawMessage](strings.NewReader(`[{"a":1}, {"b":2}]`)))
+	assert.NoError(t, err)
+	assert.Equal(t, list(json.RawMessage(`{"a":1}`), json.RawMessage(`{"b":2}`)), got, "array unwrapped for json.Unmarshaler types")
+}
+
+func TestDecodeJSONArray(t *testing.T) {
+	got, err := CollectErr(DecodeJSONArray[[]int](strings.NewReader("[[1,2],[3]]")))
+	assert.NoError(t, err)
+	assert.Equal(t, list(list(1, 2), list(3)), got, "array of arrays")
+
+	anys, err := CollectErr(DecodeJSONArray[any](strings.NewReader(`[1, [2], "x"]`)))
+	assert.NoError(t, err)
+	assert.Equal(t, list[any](1.0, list[any](2.0), "x"), anys)
+
+	_, err = CollectErr(DecodeJSONArray[int](strings.NewReader("1\n2\n")))
+	assert.ErrorContains(t, err, "not an array")
+
+	_, err = CollectErr(DecodeJSONArray[int](strings.NewReader(" ")))
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF, "missing array")
+
+	_, err = CollectErr(DecodeJSONArray[int](strings.NewReader("[1] [2]")))
+	assert.ErrorContains(t, err, "after top-level array")
+}
+
+func TestDecodeJSONLines(t *testing.T) {
+	got, err := CollectErr(DecodeJSONLines[any](strings.NewReader("[1,2]\n[3]\n")))
+	assert.NoError(t, err)
+	assert.Equal(t, list[any](list[any](1.0, 2.0), list[any](3.0)), got, "NDJSON of arrays")
+
+	ints, err := CollectErr(DecodeJSONLines[[]int](strings.NewReader("[1,2]\n[3]\n")))
+	assert.NoError(t, err)
+	assert.Equal(t, list(list(1, 2), list(3)), ints)
+
+	empty, err := CollectErr(DecodeJSONLines[int](strings.NewReader("")))
+	assert.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestDecodeJSONStreamResumes(t *testing.T) {
+	for _, src := range []string{"[1, 2, 3]", "1\n2\n3\n"} {
+		values := DecodeJSONStream[int](strings.NewReader(src))
+		for v, err := range values {
+			assert.NoError(t, err)
+			assert.Equal(t, 1, v)
+			break
+		}
+		rest, err := CollectErr(values)
+		assert.NoError(t, err)
+		assert.Equal(t, list(2, 3), rest, "second iteration of %q continues after the first", src)
+	}
+}
+
+func TestDecodeJSONStreamLazy(t *testing.T) {
+	// An array that never ends can still be decoded one element at a time.
+	src := io.MultiReader(strings.NewReader("["), &repeatReader{data: []byte("1, ")})
+	got := slices.Collect(Limit(Must(DecodeJSONStream[int](src)), 3))
+	assert.Equal(t, list(1, 1, 1), got)
+}
+
+// repeatReader endlessly repeats its data.
+type repeatReader struct {
+	data []byte
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		n += copy(p[n:], r.data)
+	}
+	return n, nil
+}
+
+func TestEncodeJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	records := list(jsonRecord{"a", 1}, jsonRecord{"b", 2})
+	require.NoError(t, EncodeJSONLines(&buf, slices.Values(records)))
+	assert.Equal(t, "{\"name\":\"a\",\"count\":1}\n{\"name\":\"b\",\"count\":2}\n", buf.String())
+
+	roundTrip, err := CollectErr(DecodeJSONStream[jsonRecord](&buf))
+	require.NoError(t, err)
+	assert.Equal(t, records, roundTrip, "round trip")
+
+	buf.Reset()
+	err = EncodeJSONLines(&buf, slices.Values(list(1.0, math.Inf(1), 2.0)))
+	var unsupported *json.UnsupportedValueError
+	assert.ErrorAs(t, err, &unsupported)
+	assert.ErrorContains(t, err, "element 1")
+	assert.Equal(t, "1\n", buf.String(), "stops at the first error")
+
+	PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+		_ = EncodeJSONLines(io.Discard, s)
+		return s
+	}).Run(t)
+}
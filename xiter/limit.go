@@ -48,3 +48,50 @@ func First[T any](it iter.Seq[T]) (value T, ok bool) {
 	}
 	return value, false
 }
+
+// Limit2 returns a new iterator that yields at most n pairs from the input
+// iterator.
+func Limit2[K, V any](it iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		i := n
+		for k, v := range it {
+			if i--; i < 0 || !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// While2 returns a new iterator function that yields pairs from the given
+// iterator function it as long as the provided condition function f returns
+// true for each pair.
+func While2[K, V any](it iter.Seq2[K, V], f func(K, V) bool) iter.Seq2[K, V] {
+	return Process2(it, func(k K, v V, yield func(K, V) bool) bool { return f(k, v) && yield(k, v) })
+}
+
+// Until2 returns a new iterator function that yields pairs from the given
+// iterator function it as long as the provided condition function f returns
+// false for each pair.
+func Until2[K, V any](it iter.Seq2[K, V], f func(K, V) bool) iter.Seq2[K, V] {
+	return Process2(it, func(k K, v V, yield func(K, V) bool) bool { return !f(k, v) && yield(k, v) })
+}
+
+// Last2 returns the last pair yielded by the given iterator function, and a
+// boolean value showing if there was a pair. An empty iterator will result in
+// zero values and false.
+func Last2[K, V any](it iter.Seq2[K, V]) (key K, value V, ok bool) {
+	for key, value = range it {
+		ok = true
+	}
+	return key, value, ok
+}
+
+// First2 returns the first pair yielded by the given iterator function, and a
+// boolean value showing if there was a pair. An empty iterator will result in
+// zero values and false.
+func First2[K, V any](it iter.Seq2[K, V]) (key K, value V, ok bool) {
+	for key, value = range it {
+		return key, value, true
+	}
+	return key, value, false
+}
@@ -107,3 +107,76 @@ func TestFirstLast(t *testing.T) {
 		}),
 	}.Run(t)
 }
+
+func TestLimit2(t *testing.T) {
+	words := []string{"a", "b", "c"}
+	TestSuite{
+		SliceCollectTest2("nilMany", Limit2(slices.All([]string(nil)), 42), nil),
+		SliceCollectTest2("someNone", Limit2(slices.All(words), 0), nil),
+		SliceCollectTest2("someTwo", Limit2(slices.All(words), 2), pairUp(list(0, 1), list("a", "b"))),
+		SliceCollectTest2("someMany", Limit2(slices.All(words), 42), pairUp(list(0, 1, 2), words)),
+
+		PanicTestCases2(func(s iter.Seq2[int, bool]) iter.Seq2[int, bool] {
+			return Limit2(s, 42)
+		}),
+	}.Run(t)
+}
+
+func TestWhileUntil2(t *testing.T) {
+	nums := []int{5, 4, 3, 2, 1}
+	indexBelow2 := func(i, _ int) bool { return i < 2 }
+	valueBelow3 := func(_, v int) bool { return v < 3 }
+	TestSuite{
+		SliceCollectTest2("nilWhile", While2(slices.All([]int(nil)), indexBelow2), nil),
+		SliceCollectTest2("nilUntil", Until2(slices.All([]int(nil)), indexBelow2), nil),
+
+		SliceCollectTest2("whileIndexBelow2", While2(slices.All(nums), indexBelow2), pairUp(list(0, 1), list(5, 4))),
+		SliceCollectTest2("untilIndexBelow2", Until2(slices.All(nums), indexBelow2), nil),
+		SliceCollectTest2("whileValueBelow3", While2(slices.All(nums), valueBelow3), nil),
+		SliceCollectTest2("untilValueBelow3", Until2(slices.All(nums), valueBelow3), pairUp(list(0, 1, 2), list(5, 4, 3))),
+
+		PanicTestCases2(func(s iter.Seq2[int, int]) iter.Seq2[int, int] {
+			return While2(s, func(int, int) bool { return true })
+		}),
+		PanicTestCases2(func(s iter.Seq2[int, int]) iter.Seq2[int, int] {
+			return Until2(s, func(int, int) bool { return false })
+		}),
+	}.Run(t)
+}
+
+type flResult2[K, V any] struct {
+	Key   K
+	Value V
+	Ok    bool
+}
+
+func TestFirstLast2(t *testing.T) {
+	firstTest := func(name string, src []string, want flResult2[int, string]) TestCase {
+		return SimpleTest(name, func(t *testing.T) flResult2[int, string] {
+			k, v, ok := First2(slices.All(src))
+			return flResult2[int, string]{k, v, ok}
+		}).Compare(want, assert.Equal).Args("match result")
+	}
+	lastTest := func(name string, src []string, want flResult2[int, string]) TestCase {
+		return SimpleTest(name, func(t *testing.T) flResult2[int, string] {
+			k, v, ok := Last2(slices.All(src))
+			return flResult2[int, string]{k, v, ok}
+		}).Compare(want, assert.Equal).Args("match result")
+	}
+
+	TestSuite{
+		firstTest("nilFirst", nil, flResult2[int, string]{}),
+		lastTest("nilLast", nil, flResult2[int, string]{}),
+		firstTest("someFirst", list("a", "b", "c"), flResult2[int, string]{0, "a", true}),
+		lastTest("someLast", list("a", "b", "c"), flResult2[int, string]{2, "c", true}),
+
+		PanicTestCases2(func(f iter.Seq2[int, int]) iter.Seq2[int, int] {
+			First2(f)
+			return f
+		}),
+		PanicTestCases2(func(f iter.Seq2[int, int]) iter.Seq2[int, int] {
+			Last2(f)
+			return f
+		}),
+	}.Run(t)
+}
@@ -1,26 +1,303 @@
 package xiter
 
 import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
 	"iter"
-	"slices"
+	"os"
+	"runtime"
 	"sync"
+
+	"github.com/cookieo9/go-std-addons/pair"
 )
 
 // Materialize returns an iterator that yield the same values as the original
 // iterator, using a cached copy of the data from the original iterator. The
-// cache is generated lazily, the first time the iterator is iterated over, and
-// is reused for all subsequent iterations. The input iterator will only be
-// iterated over once by the Materialize iterator.
+// cache is filled incrementally as values are requested, and is reused for
+// all subsequent iterations. The input iterator will only be iterated over
+// once by the Materialize iterator.
+//
+// The first reader receives each value as soon as it's produced, rather than
+// waiting for the whole input. Later readers replay the cached values, and
+// then wait for new values to be produced if they get ahead of the cache.
+// The input is only read as far as the furthest reader has gone, so an
+// indefinite iterator can be used as long as each reader stops, such as by
+// using Limit.
 //
 // The resulting iterator can be used multiple times, and can be used by
-// parallel goroutines, even when the original iterator cannot.
+// parallel goroutines, even when the original iterator cannot. If the input
+// iterator panics, the panic is re-raised for every reader that reaches the
+// same point.
 //
-// Warning: Do not use Materialize on an indefinite iterator, as the cache will
-// grow indefinitely and consume all available memory.
+// Warning: The cache holds every value read from the input iterator, so
+// reading far into an indefinite iterator will consume all available memory.
 func Materialize[T any](it iter.Seq[T]) iter.Seq[T] {
-	values := sync.OnceValue(func() []T { return slices.Collect(it) })
+	c := &materializeCache[T]{it: it}
+	c.cond.L = &c.mu
+	// A reader that stops early leaves the input paused, so it's stopped when
+	// the cache is no longer reachable.
+	runtime.SetFinalizer(c, (*materializeCache[T]).close)
+
+	return func(yield func(T) bool) {
+		for i := 0; ; i++ {
+			t, ok := c.get(i)
+			if !ok || !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// materializeCache holds the values read so far by a Materialize iterator.
+type materializeCache[T any] struct {
+	mu   sync.Mutex
+	cond sync.Cond
+
+	it       iter.Seq[T]
+	next     func() (T, bool)
+	stop     func()
+	values   []T
+	filling  bool // a reader is reading the next value from the input
+	done     bool
+	panicked bool
+	panicVal any
+}
+
+// get returns the value at index i of the input, reading from the input if it
+// isn't yet cached. It returns false if the input has fewer values.
+func (c *materializeCache[T]) get(i int) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for {
+		switch {
+		case i < len(c.values):
+			return c.values[i], true
+		case c.panicked:
+			panic(c.panicVal)
+		case c.done:
+			var zero T
+			return zero, false
+		case c.filling:
+			c.cond.Wait()
+		default:
+			c.fill()
+		}
+	}
+}
+
+// fill reads the next value from the input into the cache. The lock is
+// released while reading, with other readers waiting until it's done.
+func (c *materializeCache[T]) fill() {
+	c.filling = true
+	c.mu.Unlock()
+
+	var t T
+	ok := false
+	defer func() {
+		r := recover()
+		c.mu.Lock()
+		c.filling = false
+		switch {
+		case r != nil:
+			c.panicked, c.panicVal = true, r
+		case ok:
+			c.values = append(c.values, t)
+		default:
+			c.done = true
+		}
+		c.cond.Broadcast()
+	}()
+
+	if c.next == nil {
+		c.next, c.stop = iter.Pull(c.it)
+	}
+	t, ok = c.next()
+}
+
+// close stops the input iterator, if it has been started.
+func (c *materializeCache[T]) close() {
+	if c.stop != nil {
+		c.stop()
+	}
+}
+
+// Materialize2 is the iter.Seq2 counterpart of Materialize. It returns an
+// iterator that yields the same pairs as the original iterator, using a cache
+// that is filled incrementally as pairs are requested. The input iterator will
+// only be iterated over once by the Materialize2 iterator.
+//
+// Warning: The cache holds every pair read from the input iterator, so
+// reading far into an indefinite iterator will consume all available memory.
+func Materialize2[K, V any](it iter.Seq2[K, V]) iter.Seq2[K, V] {
+	pairs := Materialize(MapIn(it, pair.Of[K, V]))
+	return MapOut(pairs, pair.Pair[K, V].Unpack)
+}
+
+// DefaultMaterializeThreshold is the number of elements MaterializeToDisk
+// holds in memory before spilling to a file, when no other threshold is given.
+const DefaultMaterializeThreshold = 1 << 16
+
+// MaterializeOptions holds the optional settings for MaterializeToDisk. The
+// zero value uses the defaults for every setting.
+type MaterializeOptions struct {
+	// Threshold is the number of elements held in memory. Any further
+	// elements are written to a temporary file. If zero,
+	// DefaultMaterializeThreshold is used, and if negative, every element is
+	// written to the file.
+	Threshold int
+	// Dir is the directory the temporary file is created in. If empty, the
+	// default directory for temporary files is used, as with os.CreateTemp.
+	Dir string
+}
+
+// MaterializeToDisk is a version of Materialize for inputs too large to hold
+// in memory. It returns an iterator that yields the same values as the input
+// iterator, which is consumed completely the first time the returned iterator
+// is used. The first opts.Threshold values are kept in memory, and the rest are
+// written to a temporary file using codec, or GobCodec if codec is nil. Each
+// iteration then replays the values from memory, followed by those in the
+// file. If the input fits within the threshold, no file is created.
+//
+// The resulting iterator can be used multiple times, and by parallel
+// goroutines. The returned io.Closer releases the cached values and removes
+// the temporary file, and must be called once the iterator is no longer
+// needed. Using the iterator after it has been closed panics with
+// fs.ErrClosed.
+//
+// If creating, writing or reading the temporary file fails, the iterator
+// panics with the error, so that it can be recovered by xerrors.Catch. A panic
+// while reading the input, including such an error, is re-raised by every
+// later iteration.
+func MaterializeToDisk[T any](it iter.Seq[T], codec Codec[T], opts MaterializeOptions) (iter.Seq[T], io.Closer) {
+	if opts.Threshold == 0 {
+		opts.Threshold = DefaultMaterializeThreshold
+	}
+	if codec == nil {
+		codec = GobCodec[T]{}
+	}
+	c := &diskCache[T]{it: it, codec: codec, opts: opts}
 
 	return func(yield func(T) bool) {
-		slices.Values(values())(yield)
+		values, file, size := c.load()
+		for _, t := range values {
+			if !yield(t) {
+				return
+			}
+		}
+		if file == nil {
+			return
+		}
+		dec := codec.Decoder(bufio.NewReader(io.NewSectionReader(file, 0, size)))
+		for {
+			var t T
+			err := dec(&t)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				panic(err)
+			}
+			if !yield(t) {
+				return
+			}
+		}
+	}, c
+}
+
+// diskCache holds the values read by a MaterializeToDisk iterator.
+type diskCache[T any] struct {
+	mu    sync.Mutex
+	it    iter.Seq[T]
+	codec Codec[T]
+	opts  MaterializeOptions
+
+	filled   bool
+	closed   bool
+	panicked bool
+	panicVal any
+	values   []T
+	file     *os.File
+	size     int64 // the number of bytes written to file
+}
+
+// load returns the values held in memory, and the file holding the rest along
+// with its size, reading the input if this is the first call.
+func (c *diskCache[T]) load() ([]T, *os.File, int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case c.closed:
+		panic(fs.ErrClosed)
+	case c.panicked:
+		panic(c.panicVal)
+	case !c.filled:
+		c.fill()
+	}
+	return c.values, c.file, c.size
+}
+
+// fill reads the whole input, recording any panic so that it can be re-raised
+// by later iterations.
+func (c *diskCache[T]) fill() {
+	defer func() {
+		if r := recover(); r != nil {
+			c.panicked, c.panicVal = true, r
+			panic(r)
+		}
+	}()
+
+	var w *bufio.Writer
+	var enc func(T) error
+	for t := range c.it {
+		if len(c.values) < c.opts.Threshold {
+			c.values = append(c.values, t)
+			continue
+		}
+		if c.file == nil {
+			f, err := os.CreateTemp(c.opts.Dir, "xiter-materialize-*")
+			if err != nil {
+				panic(err)
+			}
+			c.file = f
+			w = bufio.NewWriter(f)
+			enc = c.codec.Encoder(w)
+		}
+		if err := enc(t); err != nil {
+			panic(err)
+		}
+	}
+	if w != nil {
+		if err := w.Flush(); err != nil {
+			panic(err)
+		}
+		size, err := c.file.Seek(0, io.SeekCurrent)
+		if err != nil {
+			panic(err)
+		}
+		c.size = size
+	}
+	c.filled = true
+}
+
+// Close releases the cached values and removes the temporary file. Closing an
+// already closed cache does nothing.
+func (c *diskCache[T]) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	c.values = nil
+	if c.file == nil {
+		return nil
 	}
+	err := errors.Join(c.file.Close(), os.Remove(c.file.Name()))
+	c.file = nil
+	return err
 }
@@ -1,10 +1,18 @@
 package xiter
 
 import (
+	"io/fs"
+	"iter"
+	"path/filepath"
 	"slices"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/cookieo9/go-std-addons/pair"
+	"github.com/cookieo9/go-std-addons/xerrors"
 )
 
 func TestMaterializeCount(t *testing.T) {
@@ -61,3 +69,165 @@ func TestMaterializeShort(t *testing.T) {
 		assert.Equal(t, wantFull, got, "got full original sequence")
 	}
 }
+
+func TestMaterialize2(t *testing.T) {
+	source := slices.All([]string{"a", "b", "c"})
+	n := 0
+	counted := func(yield func(int, string) bool) {
+		n++
+		source(yield)
+	}
+	m := Materialize2(counted)
+	assert.Equal(t, 0, n, "source iterator not yet iterated")
+
+	want := pairUp(list(0, 1, 2), list("a", "b", "c"))
+	for range 10 {
+		got := slices.Collect(MapIn(m, pair.Of[int, string]))
+		assert.Equal(t, want, got, "same sequence")
+		k, v, _ := First2(Limit2(m, 1))
+		assert.Equal(t, want[0], pair.Of(k, v), "limited sequence")
+	}
+	assert.Equal(t, 1, n, "materialized iterator prevents further iteration of source")
+
+	PanicTestCases2[int, int](Materialize2[int, int]).Run(t)
+}
+
+func TestMaterializeStreams(t *testing.T) {
+	ch := make(chan int)
+	m := Materialize(FromChan(ch))
+	next, stop := iter.Pull(m)
+	defer stop()
+
+	go func() { ch <- 1 }()
+	v, ok := next()
+	assert.Equal(t, 1, v, "first value available before the source finishes")
+	assert.True(t, ok)
+
+	go func() { ch <- 2; close(ch) }()
+	assert.Equal(t, list(1, 2), slices.Collect(m), "second reader replays then waits")
+	_, ok = next()
+	assert.True(t, ok, "first reader catches up from the cache")
+	_, ok = next()
+	assert.False(t, ok)
+}
+
+func TestMaterializeIndefinite(t *testing.T) {
+	source, n := CountUses(Count(0))
+	pulled := 0
+	m := Materialize(Map(source, func(i int) int { pulled++; return i }))
+	assert.Equal(t, list(0, 1, 2), slices.Collect(Limit(m, 3)))
+	assert.Equal(t, list(0, 1, 2, 3, 4), slices.Collect(Limit(m, 5)))
+	assert.Equal(t, list(0, 1), slices.Collect(Limit(m, 2)))
+	assert.Equal(t, 1, *n, "source started once")
+	assert.LessOrEqual(t, pulled, 6, "source only read as far as needed")
+}
+
+func TestMaterializeConcurrent(t *testing.T) {
+	m := Materialize(Map(Range(0, 1000), func(i int) int {
+		if i%100 == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		return i
+	}))
+	want := slices.Collect(Range(0, 1000))
+	results := make([][]int, 8)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = slices.Collect(m)
+		}()
+	}
+	wg.Wait()
+	for _, got := range results {
+		assert.Equal(t, want, got, "every reader sees the full sequence")
+	}
+}
+
+func TestMaterializeRepanics(t *testing.T) {
+	m := Materialize(func(yield func(int) bool) {
+		_ = yield(1) && yield(2)
+		panic(errTest)
+	})
+	for range 3 {
+		got, err := xerrors.CatchValue(func() []int { return slices.Collect(m) })
+		assert.ErrorIs(t, err, errTest, "panic re-raised for every reader")
+		assert.Nil(t, got)
+		next, stop := iter.Pull(m)
+		a, _ := next()
+		b, _ := next()
+		stop()
+		assert.Equal(t, list(1, 2), list(a, b), "values before the panic are cached")
+	}
+}
+
+func TestMaterializeToDisk(t *testing.T) {
+	want := slices.Collect(Range(0, 100))
+	disk := func(codec Codec[int], threshold int) iter.Seq[int] {
+		m, c := MaterializeToDisk(Range(0, 100), codec, MaterializeOptions{Threshold: threshold, Dir: t.TempDir()})
+		t.Cleanup(func() { assert.NoError(t, c.Close()) })
+		return m
+	}
+	TestSuite{
+		SliceCollectTest("inMemory", disk(nil, 0), want),
+		SliceCollectTest("spilled", disk(nil, 10), want),
+		SliceCollectTest("allOnDisk", disk(nil, -1), want),
+		SliceCollectTest("json", disk(JSONCodec[int]{}, 10), want),
+		SliceCollectTest("limited", Limit(disk(nil, 10), 20), want[:20]),
+	}.Run(t)
+}
+
+func TestMaterializeToDiskReuse(t *testing.T) {
+	dir := t.TempDir()
+	source, n := CountUses(Range(0, 50))
+	m, c := MaterializeToDisk(source, nil, MaterializeOptions{Threshold: 20, Dir: dir})
+	assert.Empty(t, dirEntries(t, dir), "nothing written before iterating")
+
+	want := slices.Collect(Range(0, 50))
+	assert.Equal(t, want, slices.Collect(m))
+	assert.Len(t, dirEntries(t, dir), 1, "values past the threshold spilled")
+	assert.Equal(t, want, slices.Collect(m), "replayed")
+	assert.Equal(t, 1, *n, "input iterated once")
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, want, slices.Collect(m), "concurrent readers")
+		}()
+	}
+	wg.Wait()
+
+	assert.NoError(t, c.Close())
+	assert.Empty(t, dirEntries(t, dir), "file removed on close")
+	assert.NoError(t, c.Close(), "closing twice")
+	_, err := xerrors.CatchValue(func() []int { return slices.Collect(m) })
+	assert.ErrorIs(t, err, fs.ErrClosed, "used after close")
+
+	small, c := MaterializeToDisk(Range(0, 5), nil, MaterializeOptions{Threshold: 5, Dir: dir})
+	assert.Equal(t, list(0, 1, 2, 3, 4), slices.Collect(small))
+	assert.Empty(t, dirEntries(t, dir), "no file within the threshold")
+	assert.NoError(t, c.Close())
+}
+
+func TestMaterializeToDiskErrors(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing")
+	m, c := MaterializeToDisk(Range(0, 10), nil, MaterializeOptions{Threshold: 2, Dir: missing})
+	for range 2 {
+		_, err := xerrors.CatchValue(func() []int { return slices.Collect(m) })
+		assert.ErrorIs(t, err, fs.ErrNotExist, "temporary file error reported")
+	}
+	assert.NoError(t, c.Close())
+
+	panicky, c := MaterializeToDisk(func(yield func(int) bool) {
+		_ = yield(1) && yield(2)
+		panic(errTest)
+	}, nil, MaterializeOptions{Threshold: 1})
+	for range 2 {
+		_, err := xerrors.CatchValue(func() []int { return slices.Collect(panicky) })
+		assert.ErrorIs(t, err, errTest, "input panic re-raised")
+	}
+	assert.NoError(t, c.Close(), "partial file removed")
+}
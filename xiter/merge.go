@@ -0,0 +1,139 @@
+package xiter
+
+import (
+	"iter"
+
+	"github.com/cookieo9/go-std-addons/pair"
+)
+
+// mergeCursor is the next value from one of the inputs of MergeSorted.
+type mergeCursor[T any] struct {
+	value T
+	index int
+	next  func() (T, bool)
+}
+
+// MergeSorted returns an iterator that merges the given iterators, each of
+// which must already be sorted by the comparison function cmp, into a single
+// sorted sequence. The merge is stable, so elements that compare equal are
+// yielded in the order of the iterators they came from.
+//
+// The inputs are read as the output is consumed, with one element of each
+// input held in a heap at a time, and all of them are stopped when iteration
+// finishes.
+func MergeSorted[T any](cmp func(a, b T) int, its ...iter.Seq[T]) iter.Seq[T] {
+	cursorCmp := func(a, b mergeCursor[T]) int {
+		if c := cmp(a.value, b.value); c != 0 {
+			return c
+		}
+		return a.index - b.index
+	}
+	return func(yield func(T) bool) {
+		h := make([]mergeCursor[T], 0, len(its))
+		for i, it := range its {
+			next, stop := iter.Pull(it)
+			defer stop()
+			if v, ok := next(); ok {
+				h = append(h, mergeCursor[T]{value: v, index: i, next: next})
+				heapUp(h, len(h)-1, cursorCmp)
+			}
+		}
+		for len(h) > 0 {
+			if !yield(h[0].value) {
+				return
+			}
+			if v, ok := h[0].next(); ok {
+				h[0].value = v
+			} else {
+				h[0] = h[len(h)-1]
+				h = h[:len(h)-1]
+			}
+			heapDown(h, 0, cursorCmp)
+		}
+	}
+}
+
+// MergeSortedFunc is the iter.Seq2 counterpart of MergeSorted. The given
+// iterators must each be sorted by their keys according to cmp, and they are
+// merged into a single sequence sorted by key. Pairs with equal keys are
+// yielded in the order of the iterators they came from.
+func MergeSortedFunc[K, V any](cmp func(a, b K) int, its ...iter.Seq2[K, V]) iter.Seq2[K, V] {
+	pairs := make([]iter.Seq[pair.Pair[K, V]], len(its))
+	for i, it := range its {
+		pairs[i] = MapIn(it, pair.Of[K, V])
+	}
+	merged := MergeSorted(func(a, b pair.Pair[K, V]) int { return cmp(a.A, b.A) }, pairs...)
+	return MapOut(merged, pair.Pair[K, V].Unpack)
+}
+
+// Union returns an iterator over the sorted union of a and b, which must both
+// already be sorted by cmp. When an element is in both inputs, the one from a
+// is yielded. Repeated elements are treated as a multiset, so an element that
+// appears m times in a and n times in b appears max(m, n) times in the output.
+func Union[T any](a, b iter.Seq[T], cmp func(a, b T) int) iter.Seq[T] {
+	return mergeSets(a, b, cmp, true, true, true)
+}
+
+// Intersect returns an iterator over the elements that are in both a and b,
+// which must already be sorted by cmp. The elements yielded are those from a.
+// An element that appears m times in a and n times in b appears min(m, n)
+// times in the output.
+func Intersect[T any](a, b iter.Seq[T], cmp func(a, b T) int) iter.Seq[T] {
+	return mergeSets(a, b, cmp, false, true, false)
+}
+
+// Difference returns an iterator over the elements of a that are not in b,
+// where both must already be sorted by cmp. An element that appears m times in
+// a and n times in b appears max(m-n, 0) times in the output.
+func Difference[T any](a, b iter.Seq[T], cmp func(a, b T) int) iter.Seq[T] {
+	return mergeSets(a, b, cmp, true, false, false)
+}
+
+// SymmetricDifference returns an iterator over the elements that are in
+// exactly one of a and b, which must both already be sorted by cmp. An element
+// that appears m times in a and n times in b appears |m-n| times in the
+// output.
+func SymmetricDifference[T any](a, b iter.Seq[T], cmp func(a, b T) int) iter.Seq[T] {
+	return mergeSets(a, b, cmp, true, false, true)
+}
+
+// mergeSets walks through the sorted iterators a and b in step, yielding the
+// elements that are only in a, in both, or only in b, as selected by the flags.
+func mergeSets[T any](a, b iter.Seq[T], cmp func(a, b T) int, onlyA, both, onlyB bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nextA, stopA := iter.Pull(a)
+		defer stopA()
+		nextB, stopB := iter.Pull(b)
+		defer stopB()
+
+		va, okA := nextA()
+		vb, okB := nextB()
+		for okA || okB {
+			c := -1
+			switch {
+			case !okA:
+				c = 1
+			case okB:
+				c = cmp(va, vb)
+			}
+			switch {
+			case c < 0:
+				if onlyA && !yield(va) {
+					return
+				}
+				va, okA = nextA()
+			case c > 0:
+				if onlyB && !yield(vb) {
+					return
+				}
+				vb, okB = nextB()
+			default:
+				if both && !yield(va) {
+					return
+				}
+				va, okA = nextA()
+				vb, okB = nextB()
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package xiter
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cookieo9/go-std-addons/pair"
+)
+
+func TestMergeSorted(t *testing.T) {
+	ints := func(xs ...int) iter.Seq[int] { return slices.Values(xs) }
+	compare := cmp.Compare[int]
+	TestSuite{
+		SliceCollectTest("none", MergeSorted(compare), nil),
+		SliceCollectTest("one", MergeSorted(compare, ints(1, 2, 3)), list(1, 2, 3)),
+		SliceCollectTest("two", MergeSorted(compare, ints(1, 4, 5), ints(2, 3, 6)), list(1, 2, 3, 4, 5, 6)),
+		SliceCollectTest("many", MergeSorted(compare, ints(5), ints(), ints(1, 9), ints(2, 2, 8), ints(0)), list(0, 1, 2, 2, 5, 8, 9)),
+		SliceCollectTest("infinite", Limit(MergeSorted(compare, CountUp(0, 3), CountUp(1, 3), CountUp(2, 3)), 7), list(0, 1, 2, 3, 4, 5, 6)),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			return MergeSorted(compare, ints(1, 2), s)
+		}),
+	}.Run(t)
+}
+
+func TestMergeSortedStable(t *testing.T) {
+	byA := func(a, b pair.Pair[int, string]) int { return cmp.Compare(a.A, b.A) }
+	first := slices.Values(list(pair.Of(1, "a1"), pair.Of(2, "a2"), pair.Of(2, "a2'")))
+	second := slices.Values(list(pair.Of(1, "b1"), pair.Of(2, "b2")))
+	third := slices.Values(list(pair.Of(0, "c0"), pair.Of(2, "c2")))
+	got := slices.Collect(Map(MergeSorted(byA, first, second, third), pair.Pair[int, string].Second))
+	assert.Equal(t, list("c0", "a1", "b1", "a2", "a2'", "b2", "c2"), got, "ties keep input order")
+}
+
+func TestMergeSortedStops(t *testing.T) {
+	a, aStopped := stopTracker(1, 3, 5)
+	b, bStopped := stopTracker(2, 4, 6)
+	for v := range MergeSorted(cmp.Compare[int], a, b) {
+		if v == 3 {
+			break
+		}
+	}
+	assert.True(t, *aStopped, "first source stopped")
+	assert.True(t, *bStopped, "second source stopped")
+}
+
+func TestMergeSortedFunc(t *testing.T) {
+	a := MapOut(slices.Values(list(1, 3, 3)), func(i int) (int, string) { return i, "a" })
+	b := MapOut(slices.Values(list(2, 3)), func(i int) (int, string) { return i, "b" })
+	TestSuite{
+		SliceCollectTest2("merged", MergeSortedFunc(cmp.Compare[int], a, b),
+			pairUp(list(1, 2, 3, 3, 3), list("a", "b", "a", "a", "b"))),
+		SliceCollectTest2("limited", Limit2(MergeSortedFunc(cmp.Compare[int], a, b), 2),
+			pairUp(list(1, 2), list("a", "b"))),
+
+		PanicTestCases2(func(s iter.Seq2[int, int]) iter.Seq2[int, int] {
+			return MergeSortedFunc(cmp.Compare[int], s)
+		}),
+	}.Run(t)
+}
+
+func TestSetOperations(t *testing.T) {
+	ints := func(xs ...int) iter.Seq[int] { return slices.Values(xs) }
+	compare := cmp.Compare[int]
+	a, b := ints(1, 2, 2, 2, 4, 6), ints(2, 3, 4, 5, 5)
+	TestSuite{
+		SliceCollectTest("union", Union(a, b, compare), list(1, 2, 2, 2, 3, 4, 5, 5, 6)),
+		SliceCollectTest("intersect", Intersect(a, b, compare), list(2, 4)),
+		SliceCollectTest("difference", Difference(a, b, compare), list(1, 2, 2, 6)),
+		SliceCollectTest("differenceBA", Difference(b, a, compare), list(3, 5, 5)),
+		SliceCollectTest("symmetric", SymmetricDifference(a, b, compare), list(1, 2, 2, 3, 5, 5, 6)),
+
+		SliceCollectTest("unionEmpty", Union(ints(), b, compare), list(2, 3, 4, 5, 5)),
+		SliceCollectTest("intersectEmpty", Intersect(a, ints(), compare), nil),
+		SliceCollectTest("differenceEmpty", Difference(a, ints(), compare), list(1, 2, 2, 2, 4, 6)),
+
+		SliceCollectTest("infiniteUnion", Limit(Union(CountUp(0, 2), CountUp(0, 3), compare), 6), list(0, 2, 3, 4, 6, 8)),
+		SliceCollectTest("infiniteIntersect", Limit(Intersect(CountUp(0, 2), CountUp(0, 3), compare), 3), list(0, 6, 12)),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			return Union(s, ints(1), compare)
+		}),
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			return Intersect(ints(1), s, compare)
+		}),
+	}.Run(t)
+}
+
+func TestUnionPrefersFirst(t *testing.T) {
+	byA := func(a, b pair.Pair[int, string]) int { return cmp.Compare(a.A, b.A) }
+	a := slices.Values(list(pair.Of(1, "a"), pair.Of(2, "a")))
+	b := slices.Values(list(pair.Of(2, "b"), pair.Of(3, "b")))
+	got := slices.Collect(Union(a, b, byA))
+	assert.Equal(t, list(pair.Of(1, "a"), pair.Of(2, "a"), pair.Of(3, "b")), got)
+}
@@ -0,0 +1,131 @@
+package xiter
+
+import (
+	"iter"
+	"math"
+
+	"github.com/cookieo9/go-std-addons/option"
+	"github.com/cookieo9/go-std-addons/pair"
+)
+
+// Sum returns the sum of all the elements of the input iterator. An empty
+// iterator results in zero.
+func Sum[T Countable](it iter.Seq[T]) T {
+	return Collect(it, 0, func(a, b T) T { return a + b })
+}
+
+// Product returns the product of all the elements of the input iterator. An
+// empty iterator results in one.
+func Product[T Countable](it iter.Seq[T]) T {
+	return Collect(it, 1, func(a, b T) T { return a * b })
+}
+
+// Min returns the smallest element of the input iterator, or a not-present
+// value if the iterator is empty. As with the builtin min, if any element is a
+// floating point NaN the result will be NaN.
+func Min[T Countable](it iter.Seq[T]) option.Value[T] {
+	return Reduce(it, func(a, b T) T { return min(a, b) })
+}
+
+// Max returns the largest element of the input iterator, or a not-present
+// value if the iterator is empty. As with the builtin max, if any element is a
+// floating point NaN the result will be NaN.
+func Max[T Countable](it iter.Seq[T]) option.Value[T] {
+	return Reduce(it, func(a, b T) T { return max(a, b) })
+}
+
+// MinMax returns both the smallest and largest elements of the input iterator
+// as a Pair, using a single pass over the iterator. The result is not present
+// if the iterator is empty.
+func MinMax[T Countable](it iter.Seq[T]) option.Value[pair.Pair[T, T]] {
+	pairs := Map(it, func(t T) pair.Pair[T, T] { return pair.Of(t, t) })
+	return Reduce(pairs, func(a, b pair.Pair[T, T]) pair.Pair[T, T] {
+		return pair.Of(min(a.A, b.A), max(a.B, b.B))
+	})
+}
+
+// Mean returns the arithmetic mean of the elements of the input iterator, or a
+// not-present value if the iterator is empty. The mean is computed
+// incrementally, so it does not overflow for large iterators of small
+// integer types.
+func Mean[T Countable](it iter.Seq[T]) option.Value[float64] {
+	return option.Map(Stats(it), func(s Statistics[T]) float64 { return s.Mean })
+}
+
+// MinBy returns the element of the input iterator with the smallest key as
+// produced by the function key, or a not-present value if the iterator is
+// empty. If several elements share the smallest key, the first is returned.
+func MinBy[T any, K pair.Ordered](it iter.Seq[T], key func(T) K) option.Value[T] {
+	return extremeBy(it, key, func(a, b K) bool { return a < b })
+}
+
+// MaxBy returns the element of the input iterator with the largest key as
+// produced by the function key, or a not-present value if the iterator is
+// empty. If several elements share the largest key, the first is returned.
+func MaxBy[T any, K pair.Ordered](it iter.Seq[T], key func(T) K) option.Value[T] {
+	return extremeBy(it, key, func(a, b K) bool { return a > b })
+}
+
+// extremeBy returns the first element of it whose key is better than those of
+// all the elements before it, and no worse than those after it.
+func extremeBy[T any, K pair.Ordered](it iter.Seq[T], key func(T) K, better func(a, b K) bool) option.Value[T] {
+	keyed := Map(it, func(t T) pair.Pair[T, K] { return pair.Of(t, key(t)) })
+	best := Reduce(keyed, func(a, b pair.Pair[T, K]) pair.Pair[T, K] {
+		if better(b.B, a.B) {
+			return b
+		}
+		return a
+	})
+	return option.Map(best, pair.Pair[T, K].First)
+}
+
+// Statistics holds summary statistics of a sequence of numbers, as produced by
+// Stats.
+type Statistics[T Countable] struct {
+	Count int     // the number of elements
+	Mean  float64 // the arithmetic mean of the elements
+	Min   T       // the smallest element
+	Max   T       // the largest element
+
+	m2 float64 // the sum of squared differences from the mean
+}
+
+// Variance returns the population variance of the elements.
+func (s Statistics[T]) Variance() float64 {
+	return s.m2 / float64(s.Count)
+}
+
+// SampleVariance returns the sample variance of the elements, which uses
+// Bessel's correction. It is NaN when there are fewer than two elements.
+func (s Statistics[T]) SampleVariance() float64 {
+	if s.Count < 2 {
+		return math.NaN()
+	}
+	return s.m2 / float64(s.Count-1)
+}
+
+// StdDev returns the population standard deviation of the elements.
+func (s Statistics[T]) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+// Stats consumes the input iterator in a single pass, and returns summary
+// statistics of its elements, or a not-present value if the iterator is empty.
+// The mean and variance are computed using Welford's online algorithm, which
+// is numerically stable for large iterators.
+func Stats[T Countable](it iter.Seq[T]) option.Value[Statistics[T]] {
+	s := Collect(it, Statistics[T]{}, func(s Statistics[T], t T) Statistics[T] {
+		if s.Count == 0 {
+			s.Min, s.Max = t, t
+		} else {
+			s.Min, s.Max = min(s.Min, t), max(s.Max, t)
+		}
+		s.Count++
+		x := float64(t)
+		delta := x - s.Mean
+		s.Mean += delta / float64(s.Count)
+		s.m2 += delta * (x - s.Mean)
+		return s
+	})
+	return option.Of(s, s.Count > 0)
+}
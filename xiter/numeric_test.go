@@ -0,0 +1,119 @@
+package xiter
+
+import (
+	"iter"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cookieo9/go-std-addons/option"
+	"github.com/cookieo9/go-std-addons/pair"
+)
+
+func numericTestCase[T, R any](name string, f func(iter.Seq[T]) R, src []T, want R) TestCase {
+	return SimpleTest(name, func(t *testing.T) R {
+		return f(slices.Values(src))
+	}).Compare(want, assert.Equal).Args("same result")
+}
+
+func TestSumProduct(t *testing.T) {
+	TestSuite{
+		numericTestCase("sumEmpty", Sum[int], nil, 0),
+		numericTestCase("sumInts", Sum[int], list(1, 2, 3, 4), 10),
+		numericTestCase("sumFloats", Sum[float64], list(0.5, 0.25), 0.75),
+		numericTestCase("productEmpty", Product[int], nil, 1),
+		numericTestCase("productInts", Product[int], list(1, 2, 3, 4), 24),
+		numericTestCase("productZero", Product[uint8], list[uint8](7, 0, 3), 0),
+
+		PanicTestCases(func(f iter.Seq[int]) iter.Seq[int] {
+			Sum(f)
+			return f
+		}),
+		PanicTestCases(func(f iter.Seq[int]) iter.Seq[int] {
+			Product(f)
+			return f
+		}),
+	}.Run(t)
+}
+
+func TestMinMax(t *testing.T) {
+	piDigits := list(3, 1, 4, 1, 5, 9, 2, 6)
+	TestSuite{
+		numericTestCase("minEmpty", Min[int], nil, option.None[int]()),
+		numericTestCase("minSome", Min[int], piDigits, option.Some(1)),
+		numericTestCase("maxEmpty", Max[int], nil, option.None[int]()),
+		numericTestCase("maxSome", Max[int], piDigits, option.Some(9)),
+		numericTestCase("maxNegative", Max[int8], list[int8](-3, -1, -2), option.Some[int8](-1)),
+		numericTestCase("minMaxEmpty", MinMax[int], nil, option.None[pair.Pair[int, int]]()),
+		numericTestCase("minMaxOne", MinMax[int], list(7), option.Some(pair.Of(7, 7))),
+		numericTestCase("minMaxSome", MinMax[int], piDigits, option.Some(pair.Of(1, 9))),
+
+		SimpleTest("minNaN", func(t *testing.T) bool {
+			return math.IsNaN(Min(slices.Values(list(1, math.NaN(), 0))).Require())
+		}).Compare(true, assert.Equal),
+
+		PanicTestCases(func(f iter.Seq[int]) iter.Seq[int] {
+			MinMax(f)
+			return f
+		}),
+	}.Run(t)
+}
+
+func TestMinMaxBy(t *testing.T) {
+	words := list("bb", "a", "ccc", "d", "eee")
+	length := func(s string) int { return len(s) }
+	TestSuite{
+		numericTestCase("minByEmpty", func(it iter.Seq[string]) option.Value[string] { return MinBy(it, length) }, nil, option.None[string]()),
+		numericTestCase("minBy", func(it iter.Seq[string]) option.Value[string] { return MinBy(it, length) }, words, option.Some("a")),
+		numericTestCase("maxByEmpty", func(it iter.Seq[string]) option.Value[string] { return MaxBy(it, length) }, nil, option.None[string]()),
+		numericTestCase("maxBy", func(it iter.Seq[string]) option.Value[string] { return MaxBy(it, length) }, words, option.Some("ccc")),
+
+		PanicTestCases(func(f iter.Seq[string]) iter.Seq[string] {
+			MaxBy(f, length)
+			return f
+		}),
+	}.Run(t)
+}
+
+func TestMean(t *testing.T) {
+	TestSuite{
+		numericTestCase("empty", Mean[int], nil, option.None[float64]()),
+		numericTestCase("ints", Mean[int], list(1, 2, 3, 4), option.Some(2.5)),
+		numericTestCase("noOverflow", Mean[uint8], list[uint8](250, 252, 254), option.Some(252.0)),
+
+		PanicTestCases(func(f iter.Seq[int]) iter.Seq[int] {
+			Mean(f)
+			return f
+		}),
+	}.Run(t)
+}
+
+func TestStats(t *testing.T) {
+	assert.False(t, Stats(slices.Values([]int{})).Ok(), "no stats for empty input")
+
+	s := Stats(slices.Values(list(2, 4, 4, 4, 5, 5, 7, 9))).Require()
+	assert.Equal(t, 8, s.Count)
+	assert.Equal(t, 5.0, s.Mean)
+	assert.Equal(t, 2, s.Min)
+	assert.Equal(t, 9, s.Max)
+	assert.InDelta(t, 4.0, s.Variance(), 1e-12)
+	assert.InDelta(t, 32.0/7, s.SampleVariance(), 1e-12)
+	assert.InDelta(t, 2.0, s.StdDev(), 1e-12)
+
+	one := Stats(One(3.5)).Require()
+	assert.Equal(t, 0.0, one.Variance())
+	assert.True(t, math.IsNaN(one.SampleVariance()), "sample variance needs two elements")
+
+	// A large offset defeats the naive sum-of-squares approach.
+	offset := Map(slices.Values(list(4.0, 7, 13, 16)), func(x float64) float64 { return x + 1e9 })
+	big := Stats(offset).Require()
+	assert.InDelta(t, 1e9+10, big.Mean, 1e-6)
+	assert.InDelta(t, 30.0, big.SampleVariance(), 1e-6)
+
+	PanicTestCases(func(f iter.Seq[int]) iter.Seq[int] {
+		Stats(f)
+		return f
+	}).Run(t)
+}
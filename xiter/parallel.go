@@ -0,0 +1,130 @@
+package xiter
+
+import (
+	"iter"
+	"sync"
+)
+
+// ParallelMap applies the given function f to each element of the input
+// iterator using the given number of worker goroutines, and yields the results
+// in the same order as the input. At most 2*workers elements are in flight at
+// once, so a slow consumer holds back the input rather than buffering without
+// limit.
+//
+// The input iterator is only ever used from the consumer's goroutine. If f
+// panics, the panic is re-raised on the consumer's goroutine when its result
+// is reached, so it can be recovered by tools such as xerrors.Catch. All
+// worker goroutines have exited by the time iteration finishes, including when
+// the consumer stops early. ParallelMap panics if workers is less than 1.
+func ParallelMap[T, U any](it iter.Seq[T], workers int, f func(T) U) iter.Seq[U] {
+	if workers < 1 {
+		panic("xiter: ParallelMap: workers must be at least 1")
+	}
+	return parallelMap(it, workers, f, true)
+}
+
+// UnorderedParallelMap is like ParallelMap, except that results are yielded as
+// soon as they are ready, rather than in the order of the input. This gives
+// the best throughput when the time taken by f varies between elements. A
+// panic in f is re-raised on the consumer's goroutine as soon as it occurs.
+func UnorderedParallelMap[T, U any](it iter.Seq[T], workers int, f func(T) U) iter.Seq[U] {
+	if workers < 1 {
+		panic("xiter: UnorderedParallelMap: workers must be at least 1")
+	}
+	return parallelMap(it, workers, f, false)
+}
+
+// parallelJob is a unit of work for the workers of parallelMap, and the result
+// of that work once it's done.
+type parallelJob[T, U any] struct {
+	index    int
+	input    T
+	output   U
+	done     bool
+	panicked bool
+	panicVal any
+}
+
+// run calls f on the job's input, storing the output, or the panic value if f
+// panics.
+func (j *parallelJob[T, U]) run(f func(T) U) {
+	defer func() {
+		if r := recover(); r != nil {
+			j.panicked, j.panicVal = true, r
+		}
+		j.done = true
+	}()
+	j.output = f(j.input)
+}
+
+func parallelMap[T, U any](it iter.Seq[T], workers int, f func(T) U, ordered bool) iter.Seq[U] {
+	limit := 2 * workers
+
+	return func(yield func(U) bool) {
+		next, stop := iter.Pull(it)
+		defer stop()
+
+		// Both channels can hold every job in flight, so neither the
+		// consumer nor the workers ever block sending.
+		jobs := make(chan parallelJob[T, U], limit)
+		results := make(chan parallelJob[T, U], limit)
+		quit := make(chan struct{})
+		var wg sync.WaitGroup
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := range jobs {
+					select {
+					case <-quit:
+						continue
+					default:
+					}
+					j.run(f)
+					results <- j
+				}
+			}()
+		}
+		defer wg.Wait()
+		defer close(jobs)
+		defer close(quit)
+
+		// pending is a reorder buffer, holding results that have arrived
+		// before those of earlier jobs.
+		pending := make([]parallelJob[T, U], limit)
+		sent, received := 0, 0
+		exhausted := false
+		for {
+			for ; !exhausted && sent-received < limit; sent++ {
+				t, ok := next()
+				if exhausted = !ok; exhausted {
+					break
+				}
+				jobs <- parallelJob[T, U]{index: sent, input: t}
+			}
+			if received == sent {
+				return
+			}
+
+			var j parallelJob[T, U]
+			if ordered {
+				slot := &pending[received%limit]
+				for !slot.done {
+					r := <-results
+					pending[r.index%limit] = r
+				}
+				j, *slot = *slot, parallelJob[T, U]{}
+			} else {
+				j = <-results
+			}
+			received++
+
+			if j.panicked {
+				panic(j.panicVal)
+			}
+			if !yield(j.output) {
+				return
+			}
+		}
+	}
+}
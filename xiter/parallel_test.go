@@ -0,0 +1,109 @@
+package xiter
+
+import (
+	"errors"
+	"iter"
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cookieo9/go-std-addons/xerrors"
+)
+
+// jitter sleeps for a short, varying time based on i, so that parallel work
+// finishes out of order.
+func jitter(i int) {
+	time.Sleep(time.Duration((i*7919)%5) * time.Millisecond)
+}
+
+func TestParallelMap(t *testing.T) {
+	square := func(i int) int { jitter(i); return i * i }
+	want := slices.Collect(Map(Range(0, 50), func(i int) int { return i * i }))
+	TestSuite{
+		SliceCollectTest("empty", ParallelMap(slices.Values([]int{}), 4, square), nil),
+		SliceCollectTest("one", ParallelMap(Range(0, 50), 1, square), want),
+		SliceCollectTest("many", ParallelMap(Range(0, 50), 8, square), want),
+		SliceCollectTest("moreWorkers", ParallelMap(Range(0, 3), 16, square), want[:3]),
+		SliceCollectTest("infinite", Limit(ParallelMap(Count(0), 4, square), 5), want[:5]),
+
+		SimpleTest("zeroWorkers", func(t *testing.T) iter.Seq[int] {
+			return ParallelMap(Range(0, 3), 0, square)
+		}).PanicsWith("xiter: ParallelMap: workers must be at least 1"),
+		SimpleTest("zeroWorkersUnordered", func(t *testing.T) iter.Seq[int] {
+			return UnorderedParallelMap(Range(0, 3), 0, square)
+		}).PanicsWith("xiter: UnorderedParallelMap: workers must be at least 1"),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			return ParallelMap(s, 4, square)
+		}),
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			return UnorderedParallelMap(s, 4, square)
+		}),
+	}.Run(t)
+}
+
+func TestUnorderedParallelMap(t *testing.T) {
+	square := func(i int) int { jitter(i); return i * i }
+	got := slices.Collect(UnorderedParallelMap(Range(0, 50), 8, square))
+	want := slices.Collect(Map(Range(0, 50), func(i int) int { return i * i }))
+	assert.ElementsMatch(t, want, got, "same elements in any order")
+
+	assert.Len(t, slices.Collect(Limit(UnorderedParallelMap(Count(0), 4, square), 5)), 5)
+}
+
+func TestParallelMapInFlight(t *testing.T) {
+	var pulled atomic.Int64
+	src := Map(Count(0), func(i int) int { pulled.Add(1); return i })
+	yielded := int64(0)
+	for range ParallelMap(src, 3, func(i int) int { return i }) {
+		yielded++
+		time.Sleep(time.Millisecond)
+		assert.LessOrEqual(t, pulled.Load()-yielded, int64(6), "input held back by slow consumer")
+		if yielded > 20 {
+			break
+		}
+	}
+}
+
+func TestParallelMapCleanup(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for _, ordered := range list(true, false) {
+		f := func(i int) int { jitter(i); return i }
+		for range parallelMap(Count(0), 8, f, ordered) {
+			break
+		}
+	}
+	assert.LessOrEqual(t, waitForGoroutines(before), before, "workers have exited")
+}
+
+func TestParallelMapPanics(t *testing.T) {
+	errBoom := errors.New("boom")
+	f := func(i int) int {
+		if i == 10 {
+			panic(errBoom)
+		}
+		return i
+	}
+	for name, mapper := range map[string]func(iter.Seq[int], int, func(int) int) iter.Seq[int]{
+		"ordered":   ParallelMap[int, int],
+		"unordered": UnorderedParallelMap[int, int],
+	} {
+		t.Run(name, func(t *testing.T) {
+			var got []int
+			err := xerrors.Catch(func() {
+				for v := range mapper(Range(0, 100), 4, f) {
+					got = append(got, v)
+				}
+			})
+			assert.ErrorIs(t, err, errBoom, "panic re-raised on consumer")
+			assert.NotContains(t, got, 10, "no result for panicking element")
+			if name == "ordered" {
+				assert.Equal(t, slices.Collect(Range(0, 10)), got, "results before the panic")
+			}
+		})
+	}
+}
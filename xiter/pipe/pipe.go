@@ -1,6 +1,7 @@
 package pipe
 
 import (
+	"context"
 	"fmt"
 	"iter"
 	"reflect"
@@ -162,10 +163,127 @@ func Limit[T any](n int) ProcessorFunc[T, T] {
 }
 
 // Materialize returns a new iterator that materializes the input iterator,
-// ensuring that all elements are evaluated once and stored in memory. This can
-// be useful when you want to reuse an iterator multiple times without
-// re-evaluating the input iterator, such as when the input is expensive to
-// evaluate or when it can only be evaluated once.
+// ensuring that each element is evaluated once, as it's first needed, and
+// stored in memory. This can be useful when you want to reuse an iterator
+// multiple times without re-evaluating the input iterator, such as when the
+// input is expensive to evaluate or when it can only be evaluated once.
 func Materialize[T any]() ProcessorFunc[T, T] {
 	return func(in iter.Seq[T]) iter.Seq[T] { return xiter.Materialize(in) }
 }
+
+// Concat returns a new iterator that yields all the elements of the input
+// iterator, followed by all the elements of each of the given iterators.
+func Concat[T any](its ...iter.Seq[T]) ProcessorFunc[T, T] {
+	return func(in iter.Seq[T]) iter.Seq[T] {
+		return xiter.Concat(append([]iter.Seq[T]{in}, its...)...)
+	}
+}
+
+// Flatten returns a new iterator that yields all the elements of each iterator
+// produced by the input iterator, in order.
+func Flatten[T any]() ProcessorFunc[iter.Seq[T], T] {
+	return func(in iter.Seq[iter.Seq[T]]) iter.Seq[T] { return xiter.Flatten(in) }
+}
+
+// FlattenSlices returns a new iterator that yields all the elements of each
+// slice produced by the input iterator, in order.
+func FlattenSlices[T any]() ProcessorFunc[[]T, T] {
+	return func(in iter.Seq[[]T]) iter.Seq[T] { return xiter.FlattenSlices(in) }
+}
+
+// FlatMap applies the given function f to each element in the input iterator,
+// returning a new iterator with all the elements of each resulting iterator.
+func FlatMap[T, U any](f func(T) iter.Seq[U]) ProcessorFunc[T, U] {
+	return func(in iter.Seq[T]) iter.Seq[U] { return xiter.FlatMap(in, f) }
+}
+
+// Chunk returns a new iterator that yields consecutive, non-overlapping slices
+// of n elements from the input iterator, where the final slice may be short.
+func Chunk[T any](n int) ProcessorFunc[T, []T] {
+	return func(in iter.Seq[T]) iter.Seq[[]T] { return xiter.Chunk(in, n) }
+}
+
+// Window returns a new iterator that yields overlapping slices of the most
+// recent n elements of the input iterator. The yielded slices share a buffer,
+// and are only valid until the next slice is requested.
+func Window[T any](n int) ProcessorFunc[T, []T] {
+	return func(in iter.Seq[T]) iter.Seq[[]T] { return xiter.Window(in, n) }
+}
+
+// ChunkBy returns a new iterator that yields slices of consecutive elements of
+// the input iterator, starting a new slice whenever the split function returns
+// true for the previous and current elements.
+func ChunkBy[T any](split func(prev, cur T) bool) ProcessorFunc[T, []T] {
+	return func(in iter.Seq[T]) iter.Seq[[]T] { return xiter.ChunkBy(in, split) }
+}
+
+// TopN returns a new iterator that consumes the entire input iterator, then
+// yields its n largest elements, as determined by cmp, from largest to
+// smallest. Only n elements are held in memory while consuming the input.
+func TopN[T any](n int, cmp func(a, b T) int) ProcessorFunc[T, T] {
+	return func(in iter.Seq[T]) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			slices.Values(xiter.TopN(in, n, cmp))(yield)
+		}
+	}
+}
+
+// BottomN returns a new iterator that consumes the entire input iterator, then
+// yields its n smallest elements, as determined by cmp, from smallest to
+// largest. Only n elements are held in memory while consuming the input.
+func BottomN[T any](n int, cmp func(a, b T) int) ProcessorFunc[T, T] {
+	return func(in iter.Seq[T]) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			slices.Values(xiter.BottomN(in, n, cmp))(yield)
+		}
+	}
+}
+
+// Reduce returns a new iterator that consumes the entire input iterator,
+// combining its elements using the function f, and yields the result. Nothing
+// is yielded if the input iterator is empty.
+func Reduce[T any](f func(T, T) T) ProcessorFunc[T, T] {
+	return func(in iter.Seq[T]) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			xiter.Reduce(in, f).Do(func(v T) { yield(v) })
+		}
+	}
+}
+
+// Scan returns a new iterator that yields the running accumulated value after
+// applying the function f to each element of the input iterator.
+func Scan[T, Accum any](start Accum, f func(Accum, T) Accum) ProcessorFunc[T, Accum] {
+	return func(in iter.Seq[T]) iter.Seq[Accum] { return xiter.Scan(in, start, f) }
+}
+
+// FoldWhile returns a new iterator that accumulates elements of the input
+// iterator using the function f until it returns false or the input runs out,
+// then yields the final accumulated value.
+func FoldWhile[T, Accum any](start Accum, f func(Accum, T) (Accum, bool)) ProcessorFunc[T, Accum] {
+	return func(in iter.Seq[T]) iter.Seq[Accum] {
+		return func(yield func(Accum) bool) {
+			yield(xiter.FoldWhile(in, start, f))
+		}
+	}
+}
+
+// ParallelMap applies the given function f to each element in the input
+// iterator using the given number of worker goroutines, returning a new
+// iterator with the transformed elements in their original order.
+func ParallelMap[T, U any](workers int, f func(T) U) ProcessorFunc[T, U] {
+	return func(in iter.Seq[T]) iter.Seq[U] { return xiter.ParallelMap(in, workers, f) }
+}
+
+// UnorderedParallelMap applies the given function f to each element in the
+// input iterator using the given number of worker goroutines, returning a new
+// iterator with the transformed elements in the order they are completed.
+func UnorderedParallelMap[T, U any](workers int, f func(T) U) ProcessorFunc[T, U] {
+	return func(in iter.Seq[T]) iter.Seq[U] { return xiter.UnorderedParallelMap(in, workers, f) }
+}
+
+// WithContext returns a new iterator that yields the elements of the input
+// iterator until the given context is done, at which point it panics with the
+// context's error.
+func WithContext[T any](ctx context.Context) ProcessorFunc[T, T] {
+	return func(in iter.Seq[T]) iter.Seq[T] { return xiter.WithContext(ctx, in) }
+}
@@ -1,11 +1,16 @@
 package pipe_test
 
 import (
+	"cmp"
+	"context"
+	"errors"
+	"iter"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cookieo9/go-std-addons/xiter"
 	"github.com/cookieo9/go-std-addons/xiter/pipe"
 )
 
@@ -63,3 +68,108 @@ func TestJoins(t *testing.T) {
 		})
 	}
 }
+
+func TestFlatteningPipeline(t *testing.T) {
+	data := []int{1, 2, 3}
+	want := []int{0, 0, 1, 0, 1, 2, 10, 11, 0, 1}
+
+	got, err := pipe.ProcessSlice[int](data,
+		pipe.FlatMap(func(n int) iter.Seq[int] { return xiter.Range(0, n) }),
+		pipe.Concat(xiter.Range(10, 12)),
+		pipe.Map(func(x int) []int { return []int{x} }),
+		pipe.FlattenSlices[int](),
+		pipe.Map(func(x int) iter.Seq[int] { return xiter.One(x) }),
+		pipe.Flatten[int](),
+		pipe.Concat(xiter.Range(0, 2)),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, want, got, "same sequence")
+}
+
+func TestChunkingPipeline(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7}
+	sum := func(xs []int) int {
+		total := 0
+		for _, x := range xs {
+			total += x
+		}
+		return total
+	}
+
+	got, err := pipe.ProcessSlice[int](data,
+		pipe.Window[int](2),
+		pipe.Map(sum),
+		pipe.Chunk[int](2),
+		pipe.Map(sum),
+		pipe.ChunkBy(func(prev, cur int) bool { return cur > 10 }),
+		pipe.Map(sum),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []int{8, 16, 24}, got, "same sequence")
+}
+
+func TestTopBottomPipeline(t *testing.T) {
+	data := []int{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5}
+
+	got, err := pipe.ProcessSlice[int](data,
+		pipe.TopN(5, cmp.Compare[int]),
+		pipe.BottomN(2, cmp.Compare[int]),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []int{5, 5}, got, "same sequence")
+}
+
+func TestFoldingPipeline(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	sum := func(a, b int) int { return a + b }
+
+	got, err := pipe.ProcessSlice[int](data,
+		pipe.Scan(0, sum),
+		pipe.FoldWhile(0, func(accum, x int) (int, bool) { return accum + x, x < 6 }),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []int{10}, got, "running totals until one reaches 6")
+
+	got, err = pipe.ProcessSlice[int](data, pipe.Reduce(sum))
+	require.NoError(t, err)
+	assert.Equal(t, []int{15}, got, "reduced to a single value")
+
+	got, err = pipe.ProcessSlice[int]([]int{}, pipe.Reduce(sum))
+	require.NoError(t, err)
+	assert.Empty(t, got, "nothing to reduce")
+}
+
+func TestParallelPipeline(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6}
+	double := func(x int) int { return x * 2 }
+
+	got, err := pipe.ProcessSlice[int](data, pipe.ParallelMap(3, double))
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 4, 6, 8, 10, 12}, got, "same sequence")
+
+	got, err = pipe.ProcessSlice[int](data, pipe.UnorderedParallelMap(3, double))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{2, 4, 6, 8, 10, 12}, got, "same elements")
+
+	errBad := errors.New("bad value")
+	_, err = pipe.ProcessSlice[int](data, pipe.ParallelMap(3, func(x int) int {
+		if x == 4 {
+			panic(errBad)
+		}
+		return x
+	}))
+	assert.ErrorIs(t, err, errBad, "worker panic reported as error")
+}
+
+func TestContextPipeline(t *testing.T) {
+	data := []int{1, 2, 3}
+
+	got, err := pipe.ProcessSlice[int](data, pipe.WithContext[int](context.Background()))
+	require.NoError(t, err)
+	assert.Equal(t, data, got, "same sequence")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = pipe.ProcessSlice[int](data, pipe.WithContext[int](ctx))
+	assert.ErrorIs(t, err, context.Canceled, "cancellation reported as error")
+}
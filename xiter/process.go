@@ -11,3 +11,13 @@ func Process[T, U any](it iter.Seq[T], f func(T, func(U) bool) bool) iter.Seq[U]
 		it(func(t T) bool { return f(t, yield) })
 	}
 }
+
+// Process2 is the iter.Seq2 counterpart of Process. It accepts a function that
+// will be called for each pair of elements in the iterator, and it is expected
+// to use a provided function to yield any number of pairs, or even none at all
+// for the current pair.
+func Process2[K, V, K2, V2 any](it iter.Seq2[K, V], f func(K, V, func(K2, V2) bool) bool) iter.Seq2[K2, V2] {
+	return func(yield func(K2, V2) bool) {
+		it(func(k K, v V) bool { return f(k, v, yield) })
+	}
+}
@@ -0,0 +1,104 @@
+package xiter
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"iter"
+)
+
+// Lines returns a SeqErr that yields each line read from r, without its
+// trailing end-of-line marker. It is equivalent to calling Split with
+// bufio.ScanLines. A read error, including a line longer than
+// bufio.MaxScanTokenSize, is yielded as the final element.
+//
+// As with all the reader-backed sources, the reader is consumed as the SeqErr
+// is used, so iterating a second time continues from where the first stopped.
+// Any data buffered by the first iteration is kept for the next, so none is
+// lost, but the SeqErr must not be iterated by more than one loop at a time.
+func Lines(r io.Reader) iter.Seq2[string, error] {
+	return Split(r, bufio.ScanLines)
+}
+
+// Split returns a SeqErr that yields each token read from r by a bufio.Scanner
+// using the split function split. Tokens may be at most
+// bufio.MaxScanTokenSize bytes long; use SplitBuffer for a different limit. A
+// read error is yielded as the final element.
+func Split(r io.Reader, split bufio.SplitFunc) iter.Seq2[string, error] {
+	return SplitBuffer(r, split, bufio.MaxScanTokenSize)
+}
+
+// SplitBuffer is like Split, except that tokens may be at most maxTokenSize
+// bytes long. A longer token results in bufio.ErrTooLong being yielded.
+func SplitBuffer(r io.Reader, split bufio.SplitFunc, maxTokenSize int) iter.Seq2[string, error] {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, maxTokenSize)
+	s.Split(split)
+	return func(yield func(string, error) bool) {
+		for s.Scan() {
+			if !yield(s.Text(), nil) {
+				return
+			}
+		}
+		if err := s.Err(); err != nil {
+			yield("", err)
+		}
+	}
+}
+
+// Blocks returns a SeqErr that yields consecutive blocks of size bytes read
+// from r. The final block may be shorter if the reader runs out. Each block is
+// newly allocated, and may be retained by the caller. A read error is yielded
+// as the final element. Blocks panics if size is less than 1.
+func Blocks(r io.Reader, size int) iter.Seq2[[]byte, error] {
+	if size < 1 {
+		panic("xiter: Blocks: size must be at least 1")
+	}
+	return func(yield func([]byte, error) bool) {
+		for {
+			block := make([]byte, size)
+			n, err := io.ReadFull(r, block)
+			switch {
+			case err == nil:
+				if !yield(block, nil) {
+					return
+				}
+			case errors.Is(err, io.ErrUnexpectedEOF):
+				yield(block[:n], nil)
+				return
+			case errors.Is(err, io.EOF):
+				return
+			default:
+				if n == 0 || yield(block[:n], nil) {
+					yield(nil, err)
+				}
+				return
+			}
+		}
+	}
+}
+
+// Runes returns a SeqErr that yields each UTF-8 encoded rune read from r.
+// Invalid encodings are yielded as utf8.RuneError. A read error is yielded as
+// the final element.
+func Runes(r io.Reader) iter.Seq2[rune, error] {
+	br, ok := r.(io.RuneReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return func(yield func(rune, error) bool) {
+		for {
+			c, _, err := br.ReadRune()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(0, err)
+				return
+			}
+			if !yield(c, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package xiter
+
+import (
+	"bufio"
+	"io"
+	"iter"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	TestSuite{
+		collectErrTestCase("empty", Lines(strings.NewReader("")), nil, nil),
+		collectErrTestCase("lines", Lines(strings.NewReader("a\nbb\r\n\nc")), list("a", "bb", "", "c"), nil),
+		collectErrTestCase("trailing", Lines(strings.NewReader("a\nb\n")), list("a", "b"), nil),
+		collectErrTestCase("error", Lines(io.MultiReader(strings.NewReader("a\nb"), iotest.ErrReader(errTest))), list("a", "b"), errTest),
+		collectErrTestCase("tooLong", SplitBuffer(strings.NewReader("abc\nabcdef\nabc"), bufio.ScanLines, 5), list("abc"), bufio.ErrTooLong),
+		collectErrTestCase("filtered", Filter2(
+			Lines(strings.NewReader("a\n\nb")),
+			func(s string, err error) bool { return s != "" || err != nil },
+		), list("a", "b"), nil),
+	}.Run(t)
+}
+
+func TestSplit(t *testing.T) {
+	TestSuite{
+		collectErrTestCase("words", Split(strings.NewReader("  the quick\tbrown\n fox "), bufio.ScanWords), list("the", "quick", "brown", "fox"), nil),
+		collectErrTestCase("oneByteReader", Split(iotest.OneByteReader(strings.NewReader("a b")), bufio.ScanWords), list("a", "b"), nil),
+	}.Run(t)
+
+	r := strings.NewReader("one two three")
+	words := Split(r, bufio.ScanWords)
+	first, err := CollectErr(Limit2(words, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, list("one"), first)
+}
+
+func TestBlocks(t *testing.T) {
+	TestSuite{
+		collectErrTestCase("empty", Blocks(strings.NewReader(""), 2), nil, nil),
+		collectErrTestCase("exact", Blocks(strings.NewReader("abcd"), 2), list([]byte("ab"), []byte("cd")), nil),
+		collectErrTestCase("short", Blocks(strings.NewReader("abcde"), 2), list([]byte("ab"), []byte("cd"), []byte("e")), nil),
+		collectErrTestCase("slowReader", Blocks(iotest.HalfReader(strings.NewReader("abcdef")), 3), list([]byte("abc"), []byte("def")), nil),
+		collectErrTestCase("error", Blocks(io.MultiReader(strings.NewReader("abc"), iotest.ErrReader(errTest)), 2), list([]byte("ab"), []byte("c")), errTest),
+		collectErrTestCase("errorAligned", Blocks(io.MultiReader(strings.NewReader("ab"), iotest.ErrReader(errTest)), 2), list([]byte("ab")), errTest),
+
+		SimpleTest("zeroSize", func(t *testing.T) iter.Seq2[[]byte, error] {
+			return Blocks(strings.NewReader("abc"), 0)
+		}).PanicsWith("xiter: Blocks: size must be at least 1"),
+	}.Run(t)
+}
+
+func TestRunes(t *testing.T) {
+	TestSuite{
+		collectErrTestCase("empty", Runes(strings.NewReader("")), nil, nil),
+		collectErrTestCase("ascii", Runes(strings.NewReader("abc")), list('a', 'b', 'c'), nil),
+		collectErrTestCase("unicode", Runes(iotest.OneByteReader(strings.NewReader("héllo, 世界"))), []rune("héllo, 世界"), nil),
+		collectErrTestCase("invalid", Runes(strings.NewReader("a\xffb")), list('a', utf8.RuneError, 'b'), nil),
+		collectErrTestCase("error", Runes(io.MultiReader(strings.NewReader("ab"), iotest.ErrReader(errTest))), list('a', 'b'), errTest),
+	}.Run(t)
+}
+
+func TestReaderResumes(t *testing.T) {
+	lines := Lines(strings.NewReader("a\nb\nc\n"))
+	for line, err := range lines {
+		assert.NoError(t, err)
+		assert.Equal(t, "a", line)
+		break
+	}
+	rest, err := CollectErr(lines)
+	assert.NoError(t, err)
+	assert.Equal(t, list("b", "c"), rest, "second iteration continues after the first")
+
+	runes := Runes(iotest.OneByteReader(strings.NewReader("abc")))
+	for c, err := range runes {
+		assert.NoError(t, err)
+		assert.Equal(t, 'a', c)
+		break
+	}
+	rest2, err := CollectErr(runes)
+	assert.NoError(t, err)
+	assert.Equal(t, list('b', 'c'), rest2, "buffered runes kept")
+}
@@ -0,0 +1,47 @@
+package xiter
+
+import (
+	"iter"
+
+	"github.com/cookieo9/go-std-addons/option"
+)
+
+// Index returns the position of the first element of the input iterator for
+// which the predicate function pred returns true, or a not-present value if
+// there is no such element. The iterator is stopped as soon as a match is
+// found.
+func Index[T any](it iter.Seq[T], pred func(T) bool) option.Value[int] {
+	for i, t := range Enumerate(it) {
+		if pred(t) {
+			return option.Some(i)
+		}
+	}
+	return option.None[int]()
+}
+
+// Contains reports whether the value v is produced by the input iterator. The
+// iterator is stopped as soon as the value is found.
+func Contains[T comparable](it iter.Seq[T], v T) bool {
+	return Any(it, func(t T) bool { return t == v })
+}
+
+// Any reports whether the predicate function pred returns true for any element
+// of the input iterator. The iterator is stopped as soon as a match is found,
+// and an empty iterator results in false.
+func Any[T any](it iter.Seq[T], pred func(T) bool) bool {
+	return Index(it, pred).Ok()
+}
+
+// All reports whether the predicate function pred returns true for every
+// element of the input iterator. The iterator is stopped as soon as an element
+// doesn't match, and an empty iterator results in true.
+func All[T any](it iter.Seq[T], pred func(T) bool) bool {
+	return !Any(it, func(t T) bool { return !pred(t) })
+}
+
+// None reports whether the predicate function pred returns false for every
+// element of the input iterator. The iterator is stopped as soon as an element
+// matches, and an empty iterator results in true.
+func None[T any](it iter.Seq[T], pred func(T) bool) bool {
+	return !Any(it, pred)
+}
@@ -0,0 +1,77 @@
+package xiter
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cookieo9/go-std-addons/option"
+)
+
+func searchTestCase[R any](name string, f func(iter.Seq[int]) R, src []int, want R) TestCase {
+	return SimpleTest(name, func(t *testing.T) R {
+		return f(slices.Values(src))
+	}).Compare(want, assert.Equal).Args("same result")
+}
+
+func TestIndex(t *testing.T) {
+	isEven := func(it iter.Seq[int]) option.Value[int] {
+		return Index(it, func(i int) bool { return i%2 == 0 })
+	}
+	TestSuite{
+		searchTestCase("nil", isEven, nil, option.None[int]()),
+		searchTestCase("missing", isEven, list(1, 3, 5), option.None[int]()),
+		searchTestCase("first", isEven, list(2, 3, 4), option.Some(0)),
+		searchTestCase("later", isEven, list(1, 3, 4, 6), option.Some(2)),
+		SimpleTest("infinite", func(t *testing.T) option.Value[int] {
+			return Index(Count(100), func(i int) bool { return i == 142 })
+		}).Compare(option.Some(42), assert.Equal),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			Index(s, func(int) bool { return false })
+			return s
+		}),
+	}.Run(t)
+}
+
+func TestAnyAllNone(t *testing.T) {
+	pos := func(i int) bool { return i > 0 }
+	anyPos := func(it iter.Seq[int]) bool { return Any(it, pos) }
+	allPos := func(it iter.Seq[int]) bool { return All(it, pos) }
+	nonePos := func(it iter.Seq[int]) bool { return None(it, pos) }
+	contains3 := func(it iter.Seq[int]) bool { return Contains(it, 3) }
+	TestSuite{
+		searchTestCase("anyEmpty", anyPos, nil, false),
+		searchTestCase("anySome", anyPos, list(-1, 0, 1), true),
+		searchTestCase("anyNone", anyPos, list(-1, 0), false),
+		searchTestCase("allEmpty", allPos, nil, true),
+		searchTestCase("allSome", allPos, list(-1, 0, 1), false),
+		searchTestCase("allAll", allPos, list(1, 2), true),
+		searchTestCase("noneEmpty", nonePos, nil, true),
+		searchTestCase("noneSome", nonePos, list(-1, 0, 1), false),
+		searchTestCase("noneNone", nonePos, list(-1, 0), true),
+		searchTestCase("containsEmpty", contains3, nil, false),
+		searchTestCase("containsYes", contains3, list(1, 2, 3), true),
+		searchTestCase("containsNo", contains3, list(1, 2, 4), false),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			All(s, pos)
+			return s
+		}),
+	}.Run(t)
+}
+
+func TestSearchStops(t *testing.T) {
+	src, stopped := stopTracker(1, 2, 3)
+	n := 0
+	counted := Map(src, func(i int) int { n++; return i })
+	assert.True(t, Contains(counted, 2), "found value")
+	assert.True(t, *stopped, "source stopped")
+	assert.Equal(t, 2, n, "no elements consumed after the match")
+
+	assert.True(t, Any(Count(0), func(i int) bool { return i > 10 }), "stops infinite iterator")
+	assert.False(t, All(Count(0), func(i int) bool { return i < 10 }), "stops infinite iterator")
+	assert.False(t, None(Count(0), func(i int) bool { return i > 10 }), "stops infinite iterator")
+}
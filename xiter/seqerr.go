@@ -0,0 +1,109 @@
+package xiter
+
+import (
+	"iter"
+
+	"github.com/cookieo9/go-std-addons/xerrors"
+)
+
+// Must converts a SeqErr into a plain iterator of its values. If the SeqErr
+// yields an error, the iterator panics with it, so that the error can be
+// recovered by xerrors.Catch.
+func Must[T any](s iter.Seq2[T, error]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for t, err := range s {
+			if err != nil {
+				panic(err)
+			}
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// Try converts an iterator that may panic with an error into a SeqErr. The
+// function f is called to create the iterator each time the SeqErr is used. If
+// creating or running the iterator panics with an error, the error is yielded
+// as the final element. Panics with values that are not errors, and panics in
+// the consumer's loop body, are not recovered.
+func Try[T any](f func() iter.Seq[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		inYield, stopped := false, false
+		err := xerrors.Catch(func() {
+			for t := range f() {
+				inYield = true
+				if !yield(t, nil) {
+					stopped = true
+					return
+				}
+				inYield = false
+			}
+		})
+		switch {
+		case err != nil && inYield:
+			panic(err)
+		case err != nil && !stopped:
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// MapErr applies the given function f to each value of the input SeqErr, and
+// yields the results. An error from either the input or f is yielded, and
+// ends the iteration.
+func MapErr[T, U any](s iter.Seq2[T, error], f func(T) (U, error)) iter.Seq2[U, error] {
+	return func(yield func(U, error) bool) {
+		for t, err := range s {
+			var u U
+			if err == nil {
+				u, err = f(t)
+			}
+			if err != nil {
+				var zero U
+				yield(zero, err)
+				return
+			}
+			if !yield(u, nil) {
+				return
+			}
+		}
+	}
+}
+
+// FilterErr applies the given predicate function f to each value of the input
+// SeqErr, and yields only the values for which f returns true. An error from
+// either the input or f is yielded, and ends the iteration.
+func FilterErr[T any](s iter.Seq2[T, error], f func(T) (bool, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for t, err := range s {
+			keep := false
+			if err == nil {
+				keep, err = f(t)
+			}
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			if keep && !yield(t, nil) {
+				return
+			}
+		}
+	}
+}
+
+// CollectErr collects the values of the input SeqErr into a slice, stopping at
+// the first error. The values collected before the error are returned along
+// with it.
+func CollectErr[T any](s iter.Seq2[T, error]) ([]T, error) {
+	var out []T
+	for t, err := range s {
+		if err != nil {
+			return out, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
@@ -0,0 +1,119 @@
+package xiter
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cookieo9/go-std-addons/xerrors"
+)
+
+var errTest = errors.New("test error")
+
+// seqErrOf returns a SeqErr that yields the given values, followed by err if
+// it isn't nil.
+func seqErrOf[T any](err error, values ...T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for _, v := range values {
+			if !yield(v, nil) {
+				return
+			}
+		}
+		if err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+type collectErrResult[T any] struct {
+	Values []T
+	Err    error
+}
+
+func collectErrTestCase[T any](name string, s iter.Seq2[T, error], want []T, wantErr error) TestCase {
+	return SimpleTest(name, func(t *testing.T) collectErrResult[T] {
+		values, err := CollectErr(s)
+		return collectErrResult[T]{values, err}
+	}).Compare(collectErrResult[T]{want, wantErr}, assert.Equal).Args("same result")
+}
+
+func TestCollectErr(t *testing.T) {
+	TestSuite{
+		collectErrTestCase("empty", seqErrOf[int](nil), nil, nil),
+		collectErrTestCase("values", seqErrOf(nil, 1, 2), list(1, 2), nil),
+		collectErrTestCase("error", seqErrOf(errTest, 1, 2), list(1, 2), errTest),
+		collectErrTestCase("onlyError", seqErrOf[int](errTest), nil, errTest),
+		collectErrTestCase("mapped", MapOut(Range(0, 2), func(i int) (int, error) { return i, nil }), list(0, 1), nil),
+	}.Run(t)
+}
+
+func TestMust(t *testing.T) {
+	TestSuite{
+		SliceCollectTest("values", Must(seqErrOf(nil, 1, 2)), list(1, 2)),
+		SliceCollectTest("limited", Limit(Must(seqErrOf(errTest, 1, 2)), 1), list(1)),
+		SliceCollectTest("error", Must(seqErrOf(errTest, 1, 2)), nil).PanicsError(errTest),
+	}.Run(t)
+}
+
+func TestTry(t *testing.T) {
+	panicky := func() iter.Seq[int] {
+		return Map(Range(0, 5), func(i int) int {
+			if i == 2 {
+				panic(errTest)
+			}
+			return i
+		})
+	}
+	TestSuite{
+		collectErrTestCase("values", Try(func() iter.Seq[int] { return Range(0, 3) }), list(0, 1, 2), nil),
+		collectErrTestCase("panic", Try(panicky), list(0, 1), errTest),
+		collectErrTestCase("create", Try(func() iter.Seq[int] { panic(errTest) }), nil, errTest),
+		collectErrTestCase("roundTrip", Try(func() iter.Seq[int] { return Must(seqErrOf(errTest, 1)) }), list(1), errTest),
+		SliceCollectTest("nonError", Must(Try(func() iter.Seq[int] { panic("not an error") })), nil).
+			PanicsWith("not an error"),
+	}.Run(t)
+
+	var got []int
+	for v := range Try(panicky) {
+		got = append(got, v)
+		break
+	}
+	assert.Equal(t, list(0), got, "stops early")
+
+	err := xerrors.Catch(func() {
+		for range Try(func() iter.Seq[int] { return Range(0, 3) }) {
+			panic(errTest)
+		}
+	})
+	assert.ErrorIs(t, err, errTest, "consumer panics are not converted")
+}
+
+func TestMapFilterErr(t *testing.T) {
+	atoi := func(s string) (int, error) { return strconv.Atoi(s) }
+	notZero := func(i int) (bool, error) { return i != 0, nil }
+	noThrees := func(i int) (bool, error) {
+		if i == 3 {
+			return false, errTest
+		}
+		return true, nil
+	}
+	strs := func(values ...string) iter.Seq2[string, error] { return seqErrOf(nil, values...) }
+	_, errSyntax := strconv.Atoi("x")
+	TestSuite{
+		collectErrTestCase("map", MapErr(strs("1", "2"), atoi), list(1, 2), nil),
+		collectErrTestCase("mapError", MapErr(strs("1", "x", "2"), atoi), list(1), errSyntax),
+		collectErrTestCase("mapSourceError", MapErr(seqErrOf(errTest, "1"), atoi), list(1), errTest),
+		collectErrTestCase("filter", FilterErr(seqErrOf(nil, 0, 1, 0, 2), notZero), list(1, 2), nil),
+		collectErrTestCase("filterError", FilterErr(seqErrOf(nil, 1, 2, 3, 4), noThrees), list(1, 2), errTest),
+		collectErrTestCase("filterSourceError", FilterErr(seqErrOf(errTest, 0, 1), notZero), list(1), errTest),
+		collectErrTestCase("chained", FilterErr(MapErr(strs("0", "5", "x"), atoi), notZero), list(5), errSyntax),
+	}.Run(t)
+
+	got := slices.Collect(Limit(Must(MapErr(strs("1", "2", "x"), atoi)), 1))
+	assert.Equal(t, list(1), got, "stops before the error")
+}
@@ -0,0 +1,196 @@
+package xiter
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"iter"
+	"math"
+	"math/bits"
+	"reflect"
+)
+
+// BloomUnique returns a new sequence that removes duplicate elements from the
+// input sequence using a Bloom filter, so that much less memory is used than
+// by Unique. The filter is sized for the expected number of distinct elements
+// so that, once that many have been seen, a new element is wrongly treated as
+// a duplicate with a probability of about fpRate. Duplicates are always
+// removed, but some unique elements are also lost, more so if there are more
+// distinct elements than expected.
+//
+// Elements are hashed with a randomly seeded hash of their value, which is
+// consistent with ==. Use BloomUniqueFunc to provide a different hash.
+// BloomUnique panics if expected is less than 1, or fpRate isn't between 0 and
+// 1.
+func BloomUnique[T comparable](in iter.Seq[T], expected int, fpRate float64) iter.Seq[T] {
+	checkBloomArgs("BloomUnique", expected, fpRate)
+	return func(yield func(T) bool) {
+		BloomUniqueFunc(in, expected, fpRate, hashComparable[T](maphash.MakeSeed()))(yield)
+	}
+}
+
+// BloomUniqueFunc is like BloomUnique, but uses the function hash to hash the
+// elements, so they needn't be comparable. Elements with the same hash are
+// treated as duplicates, and the hash should be evenly distributed over all
+// 64 bits, or more unique elements will be lost.
+func BloomUniqueFunc[T any](in iter.Seq[T], expected int, fpRate float64, hash func(T) uint64) iter.Seq[T] {
+	checkBloomArgs("BloomUniqueFunc", expected, fpRate)
+	// The optimal number of bits, and of hash functions, for the given rate.
+	m := uint64(math.Ceil(-float64(expected) * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	k := max(1, int(math.Round(float64(m)/float64(expected)*math.Ln2)))
+
+	return func(yield func(T) bool) {
+		filter := make([]uint64, (m+63)/64)
+		for v := range in {
+			// The k hash functions are derived from two hashes, as described
+			// by Kirsch and Mitzenmacher.
+			h1 := hash(v)
+			h2 := bits.RotateLeft64(h1, 32)*0x9e3779b97f4a7c15 | 1
+			seen := true
+			for i := range uint64(k) {
+				bit := (h1 + i*h2) % m
+				word, mask := bit/64, uint64(1)<<(bit%64)
+				if filter[word]&mask == 0 {
+					seen = false
+					filter[word] |= mask
+				}
+			}
+			if !seen && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// checkBloomArgs panics if the arguments to the function name, BloomUnique or
+// BloomUniqueFunc, are invalid.
+func checkBloomArgs(name string, expected int, fpRate float64) {
+	if expected < 1 {
+		panic("xiter: " + name + ": expected must be at least 1")
+	}
+	if !(fpRate > 0 && fpRate < 1) {
+		panic("xiter: " + name + ": fpRate must be between 0 and 1")
+	}
+}
+
+// hllPrecision is the number of bits of each hash that CountDistinct uses to
+// choose a register, giving 2^hllPrecision registers, and a standard error of
+// about 0.8%.
+const hllPrecision = 14
+
+// CountDistinct consumes the input iterator, returning an estimate of the
+// number of distinct elements in it. It uses the HyperLogLog algorithm, so the
+// memory used is small and fixed, and the estimate is usually within about 2%
+// of the true count, however long the input is.
+//
+// Elements are hashed with a randomly seeded hash of their value, which is
+// consistent with ==. Use CountDistinctFunc to provide a different hash.
+func CountDistinct[T comparable](it iter.Seq[T]) int {
+	return CountDistinctFunc(it, hashComparable[T](maphash.MakeSeed()))
+}
+
+// CountDistinctFunc is like CountDistinct, but uses the function hash to hash
+// the elements, so they needn't be comparable. Elements with the same hash are
+// counted once, and the hash should be evenly distributed over all 64 bits, or
+// the estimate will be inaccurate.
+func CountDistinctFunc[T any](it iter.Seq[T], hash func(T) uint64) int {
+	const m = 1 << hllPrecision
+	var registers [m]uint8
+	for v := range it {
+		h := hash(v)
+		// The top bits choose the register, which records the longest run of
+		// leading zeros seen in the remaining bits.
+		i := h >> (64 - hllPrecision)
+		rank := uint8(min(bits.LeadingZeros64(h<<hllPrecision), 64-hllPrecision) + 1)
+		registers[i] = max(registers[i], rank)
+	}
+
+	sum, zeros := 0.0, 0
+	for _, r := range registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// Linear counting is more accurate for small counts.
+		estimate = m * math.Log(float64(m)/float64(zeros))
+	}
+	return int(math.Round(estimate))
+}
+
+// hashComparable returns a hash function for values of the comparable type T
+// using seed, such that values that are equal with == have the same hash.
+func hashComparable[T comparable](seed maphash.Seed) func(T) uint64 {
+	return func(v T) uint64 {
+		if s, ok := any(v).(string); ok {
+			return maphash.String(seed, s)
+		}
+		var h maphash.Hash
+		h.SetSeed(seed)
+		writeHash(&h, reflect.ValueOf(&v).Elem())
+		return h.Sum64()
+	}
+}
+
+// writeHash writes the value v to h, in a form that's the same for values
+// that are equal with ==.
+func writeHash(h *maphash.Hash, v reflect.Value) {
+	writeUint := func(u uint64) {
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], u)
+		h.Write(b[:])
+	}
+	writeFloat := func(f float64) {
+		if f == 0 {
+			f = 0 // -0 == +0
+		}
+		writeUint(math.Float64bits(f))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		h.WriteString(v.String())
+		h.WriteByte(0)
+	case reflect.Bool:
+		if v.Bool() {
+			h.WriteByte(1)
+		} else {
+			h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeFloat(real(v.Complex()))
+		writeFloat(imag(v.Complex()))
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Chan:
+		writeUint(uint64(v.Pointer()))
+	case reflect.Array:
+		for i := range v.Len() {
+			writeHash(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).Name != "_" {
+				writeHash(h, v.Field(i))
+			}
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			h.WriteByte(0)
+			return
+		}
+		h.WriteByte(1)
+		h.WriteString(v.Elem().Type().String())
+		writeHash(h, v.Elem())
+	default:
+		// Only possible for the dynamic value of an interface, for which ==
+		// would also panic.
+		panic("xiter: hash of unhashable type " + v.Type().String())
+	}
+}
@@ -0,0 +1,105 @@
+package xiter
+
+import (
+	"hash/maphash"
+	"iter"
+	"math"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBloomUnique(t *testing.T) {
+	piDigits := []int{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5}
+	twice := Concat(Range(0, 1000), Range(0, 1000))
+	length := func(s string) uint64 { return uint64(len(s)) }
+	TestSuite{
+		SliceCollectTest("empty", BloomUnique(slices.Values([]int{}), 10, 0.01), nil),
+		SliceCollectTest("pidigits", BloomUnique(slices.Values(piDigits), 10, 1e-9), list(3, 1, 4, 5, 9, 2, 6)),
+		SliceCollectTest("pidigits-lim4", Limit(BloomUnique(slices.Values(piDigits), 10, 1e-9), 4), list(3, 1, 4, 5)),
+		SliceCollectTest("twice", BloomUnique(twice, 1000, 1e-9), slices.Collect(Range(0, 1000))),
+		SliceCollectTest("func", BloomUniqueFunc(slices.Values(list("a", "bb", "c", "dd", "eee")), 10, 1e-9, length), list("a", "bb", "eee")),
+
+		SimpleTest("zeroExpected", func(t *testing.T) iter.Seq[int] {
+			return BloomUnique(Range(0, 3), 0, 0.01)
+		}).PanicsWith("xiter: BloomUnique: expected must be at least 1"),
+		SimpleTest("zeroRate", func(t *testing.T) iter.Seq[int] {
+			return BloomUnique(Range(0, 3), 10, 0)
+		}).PanicsWith("xiter: BloomUnique: fpRate must be between 0 and 1"),
+		SimpleTest("oneRate", func(t *testing.T) iter.Seq[int] {
+			return BloomUnique(Range(0, 3), 10, 1)
+		}).PanicsWith("xiter: BloomUnique: fpRate must be between 0 and 1"),
+		SimpleTest("zeroRateFunc", func(t *testing.T) iter.Seq[string] {
+			return BloomUniqueFunc(slices.Values(list("a")), 10, 0, length)
+		}).PanicsWith("xiter: BloomUniqueFunc: fpRate must be between 0 and 1"),
+	}.Run(t)
+}
+
+func TestBloomUniqueFalsePositives(t *testing.T) {
+	const n = 10000
+	got := 0
+	for range BloomUnique(Range(0, n), n, 0.01) {
+		got++
+	}
+	lost := n - got
+	assert.Less(t, lost, n*2/100, "about the requested rate of unique elements lost")
+}
+
+func TestCountDistinct(t *testing.T) {
+	assert.Equal(t, 0, CountDistinct(slices.Values([]int{})), "empty")
+	assert.InDelta(t, 7, CountDistinct(slices.Values([]int{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5})), 1, "small")
+
+	for _, n := range []int{1000, 100000, 1000000} {
+		src := Map(Range(0, 3*n), func(i int) string { return strconv.Itoa(i % n) })
+		got := CountDistinct(src)
+		assert.InEpsilon(t, n, got, 0.03, "estimate for %d distinct values", n)
+	}
+
+	seed := maphash.MakeSeed()
+	byMagnitude := func(f float64) uint64 { return maphash.String(seed, strconv.Itoa(int(math.Log10(f)))) }
+	floats := Map(Range(1, 100000), func(i int) float64 { return float64(i) })
+	assert.InDelta(t, 5, CountDistinctFunc(floats, byMagnitude), 1, "custom hash")
+}
+
+func TestHashComparable(t *testing.T) {
+	type inner struct {
+		a int
+		_ int
+		s string
+	}
+	type value struct {
+		in  inner
+		f   float64
+		arr [2]any
+		p   *int
+	}
+	x, y := 1, 1
+	hash := hashComparable[value](maphash.MakeSeed())
+	v := value{inner{1, 0, "a"}, 0, [2]any{1, "b"}, &x}
+
+	same := []value{
+		v,
+		{inner{1, 0, "a"}, math.Copysign(0, -1), [2]any{1, "b"}, &x},
+	}
+	for _, w := range same {
+		assert.True(t, v == w)
+		assert.Equal(t, hash(v), hash(w), "equal values have equal hashes")
+	}
+
+	different := []value{
+		{inner{2, 0, "a"}, 0, [2]any{1, "b"}, &x},
+		{inner{1, 0, "ab"}, 0, [2]any{1, "b"}, &x},
+		{inner{1, 0, "a"}, 1, [2]any{1, "b"}, &x},
+		{inner{1, 0, "a"}, 0, [2]any{int64(1), "b"}, &x},
+		{inner{1, 0, "a"}, 0, [2]any{1, nil}, &x},
+		{inner{1, 0, "a"}, 0, [2]any{1, "b"}, &y},
+	}
+	for _, w := range different {
+		assert.False(t, v == w)
+		assert.NotEqual(t, hash(v), hash(w), "different values have different hashes")
+	}
+
+	assert.Panics(t, func() { hashComparable[any](maphash.MakeSeed())([]int{1}) }, "unhashable dynamic type")
+}
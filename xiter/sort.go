@@ -0,0 +1,170 @@
+package xiter
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"slices"
+)
+
+// DefaultRunSize is the number of elements SortExternal sorts in memory at a
+// time, when no other size is given.
+const DefaultRunSize = 1 << 16
+
+// DefaultFanIn is the number of runs SortExternal merges at a time, when no
+// other number is given.
+const DefaultFanIn = 64
+
+// SortOptions holds the optional settings for SortExternal. The zero value
+// uses the defaults for every setting.
+type SortOptions[T any] struct {
+	// RunSize is the number of elements sorted in memory at a time. If zero,
+	// DefaultRunSize is used.
+	RunSize int
+	// FanIn is the maximum number of runs merged at a time, which is also the
+	// maximum number of temporary files open at once. If zero, DefaultFanIn is
+	// used.
+	FanIn int
+	// Dir is the directory temporary files are created in. If empty, the
+	// default directory for temporary files is used, as with os.CreateTemp.
+	Dir string
+	// Codec is used to write elements to temporary files and read them back.
+	// If nil, GobCodec is used.
+	Codec Codec[T]
+}
+
+// SortExternal returns an iterator that yields the elements of the input
+// iterator sorted by the comparison function cmp, for inputs too large to sort
+// in memory. The sort is stable.
+//
+// When iterated, the input is consumed in runs of opts.RunSize elements, which
+// are sorted and written to temporary files, so that at most about twice that
+// many elements are held in memory. If there are more than opts.FanIn runs,
+// groups of consecutive runs are merged into longer runs until there are few
+// enough. The runs are then merged lazily as the output is consumed. A file is
+// only open while its run is being written or merged, so at most opts.FanIn+1
+// files are open at once. If the whole input fits in a single run, it is sorted
+// in memory without using any files. The temporary files are removed once
+// iteration finishes, including when the consumer stops early.
+//
+// If creating, writing or reading a temporary file fails, the iterator panics
+// with the error, so that it can be recovered by xerrors.Catch. SortExternal
+// panics if opts.RunSize is negative, or opts.FanIn is 1 or less, other than
+// the default of zero.
+func SortExternal[T any](it iter.Seq[T], cmp func(a, b T) int, opts SortOptions[T]) iter.Seq[T] {
+	if opts.RunSize < 0 {
+		panic("xiter: SortExternal: RunSize must not be negative")
+	}
+	if opts.RunSize == 0 {
+		opts.RunSize = DefaultRunSize
+	}
+	if opts.FanIn == 0 {
+		opts.FanIn = DefaultFanIn
+	}
+	if opts.FanIn < 2 {
+		panic("xiter: SortExternal: FanIn must be at least 2")
+	}
+	if opts.Codec == nil {
+		opts.Codec = GobCodec[T]{}
+	}
+
+	return func(yield func(T) bool) {
+		var created []string
+		defer func() {
+			for _, name := range created {
+				os.Remove(name)
+			}
+		}()
+		spill := func(run iter.Seq[T]) string {
+			f, err := os.CreateTemp(opts.Dir, "xiter-sort-*")
+			if err != nil {
+				panic(err)
+			}
+			created = append(created, f.Name())
+			err = writeRun(f, opts.Codec, run)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				panic(err)
+			}
+			return f.Name()
+		}
+		merge := func(names []string) iter.Seq[T] {
+			runs := make([]iter.Seq[T], len(names))
+			for i, name := range names {
+				runs[i] = readRun(name, opts.Codec)
+			}
+			return MergeSorted(cmp, runs...)
+		}
+
+		var names []string
+		var last []T
+		for chunk := range Chunk(it, opts.RunSize) {
+			if last != nil {
+				names = append(names, spill(slices.Values(last)))
+			}
+			slices.SortStableFunc(chunk, cmp)
+			last = chunk
+		}
+		if len(names) == 0 {
+			slices.Values(last)(yield)
+			return
+		}
+		names = append(names, spill(slices.Values(last)))
+
+		// Merging consecutive runs keeps the sort stable.
+		for len(names) > opts.FanIn {
+			var merged []string
+			for group := range slices.Chunk(names, opts.FanIn) {
+				merged = append(merged, spill(merge(group)))
+				for _, name := range group {
+					os.Remove(name)
+				}
+			}
+			names = merged
+		}
+		merge(names)(yield)
+	}
+}
+
+// writeRun writes the values from run to the file f using codec.
+func writeRun[T any](f *os.File, codec Codec[T], run iter.Seq[T]) error {
+	w := bufio.NewWriter(f)
+	enc := codec.Encoder(w)
+	for t := range run {
+		if err := enc(t); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// readRun returns an iterator over the values written to the named file by
+// writeRun. The file is only open while the iterator is running. The iterator
+// panics if reading fails.
+func readRun[T any](name string, codec Codec[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		f, err := os.Open(name)
+		if err != nil {
+			panic(err)
+		}
+		defer f.Close()
+		dec := codec.Decoder(bufio.NewReader(f))
+		for {
+			var t T
+			err := dec(&t)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				panic(err)
+			}
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package xiter
+
+import (
+	"cmp"
+	"io/fs"
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cookieo9/go-std-addons/pair"
+	"github.com/cookieo9/go-std-addons/xerrors"
+)
+
+// dirEntries returns the names of the files in dir.
+func dirEntries(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	return slices.Collect(Map(slices.Values(entries), fs.DirEntry.Name))
+}
+
+func TestSortExternal(t *testing.T) {
+	src := slices.Collect(Map(Range(0, 1000), func(i int) int { return (i * 7919) % 1009 }))
+	want := slices.Sorted(slices.Values(src))
+	compare := cmp.Compare[int]
+	TestSuite{
+		SliceCollectTest("empty", SortExternal(slices.Values([]int{}), compare, SortOptions[int]{}), nil),
+		SliceCollectTest("inMemory", SortExternal(slices.Values(src), compare, SortOptions[int]{}), want),
+		SliceCollectTest("runs", SortExternal(slices.Values(src), compare, SortOptions[int]{RunSize: 64, Dir: t.TempDir()}), want),
+		SliceCollectTest("exactRuns", SortExternal(slices.Values(src), compare, SortOptions[int]{RunSize: 100, Dir: t.TempDir()}), want),
+		SliceCollectTest("json", SortExternal(slices.Values(src), compare, SortOptions[int]{RunSize: 300, Codec: JSONCodec[int]{}}), want),
+		SliceCollectTest("limited", Limit(SortExternal(slices.Values(src), compare, SortOptions[int]{RunSize: 64}), 3), want[:3]),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			return SortExternal(s, compare, SortOptions[int]{RunSize: 2})
+		}),
+	}.Run(t)
+}
+
+func TestSortExternalStable(t *testing.T) {
+	type item = pair.Pair[int, int]
+	src := Map(Range(0, 200), func(i int) item { return pair.Of(i%7, i) })
+	got := slices.Collect(SortExternal(src, func(a, b item) int { return cmp.Compare(a.A, b.A) }, SortOptions[item]{RunSize: 16}))
+	want := slices.SortedStableFunc(Map(Range(0, 200), func(i int) item { return pair.Of(i%7, i) }),
+		func(a, b item) int { return cmp.Compare(a.A, b.A) })
+	assert.Equal(t, want, got, "equal keys keep their input order")
+}
+
+func TestSortExternalCleanup(t *testing.T) {
+	dir := t.TempDir()
+	sorted := SortExternal(Range(100, 0), cmp.Compare[int], SortOptions[int]{RunSize: 10, Dir: dir})
+
+	for v := range sorted {
+		assert.Len(t, dirEntries(t, dir), 10, "runs spilled to disk")
+		if v == 5 {
+			break
+		}
+	}
+	assert.Empty(t, dirEntries(t, dir), "files removed after stopping early")
+
+	assert.Equal(t, slices.Collect(Range(1, 101)), slices.Collect(sorted), "reusable")
+	assert.Empty(t, dirEntries(t, dir), "files removed after finishing")
+
+	err := xerrors.Catch(func() {
+		for range sorted {
+			panic(errTest)
+		}
+	})
+	assert.ErrorIs(t, err, errTest)
+	assert.Empty(t, dirEntries(t, dir), "files removed after a panic")
+
+	small := SortExternal(Range(10, 0), cmp.Compare[int], SortOptions[int]{RunSize: 10, Dir: dir})
+	for range small {
+		assert.Empty(t, dirEntries(t, dir), "single run kept in memory")
+	}
+}
+
+// openFiles returns the number of files open by the process, or -1 if it
+// can't be found.
+func openFiles() int {
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return -1
+	}
+	return len(entries)
+}
+
+func TestSortExternalFanIn(t *testing.T) {
+	type item = pair.Pair[int, int]
+	byKey := func(a, b item) int { return cmp.Compare(a.A, b.A) }
+	src := Map(Range(0, 1000), func(i int) item { return pair.Of((i*7919)%101, i) })
+	want := slices.SortedStableFunc(src, byKey)
+
+	dir := t.TempDir()
+	before := openFiles()
+	sorted := SortExternal(src, byKey, SortOptions[item]{RunSize: 3, FanIn: 4, Dir: dir})
+	var got []item
+	for v := range sorted {
+		if len(got) == 0 {
+			assert.LessOrEqual(t, len(dirEntries(t, dir)), 4, "runs merged down to the fan-in")
+			if before >= 0 {
+				assert.LessOrEqual(t, openFiles(), before+4, "at most fan-in files open")
+			}
+		}
+		got = append(got, v)
+	}
+	assert.Equal(t, want, got, "sorted and stable over several merge passes")
+	assert.Empty(t, dirEntries(t, dir), "all files removed")
+
+	assert.Equal(t, want[:5], slices.Collect(Limit(sorted, 5)))
+	assert.Empty(t, dirEntries(t, dir), "all files removed after stopping early")
+
+	assert.PanicsWithValue(t, "xiter: SortExternal: FanIn must be at least 2", func() {
+		SortExternal(src, byKey, SortOptions[item]{FanIn: 1})
+	})
+	assert.PanicsWithValue(t, "xiter: SortExternal: RunSize must not be negative", func() {
+		SortExternal(src, byKey, SortOptions[item]{RunSize: -1})
+	})
+}
+
+func TestSortExternalErrors(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing")
+	sorted := SortExternal(Range(0, 10), cmp.Compare[int], SortOptions[int]{RunSize: 2, Dir: missing})
+	_, err := xerrors.CatchValue(func() []int { return slices.Collect(sorted) })
+	assert.ErrorIs(t, err, fs.ErrNotExist, "temporary file error reported")
+
+	type unencodable struct{ F func() }
+	bad := SortExternal(Repeat(unencodable{}, 4), func(a, b unencodable) int { return 0 }, SortOptions[unencodable]{RunSize: 2})
+	_, err = xerrors.CatchValue(func() []unencodable { return slices.Collect(bad) })
+	assert.Error(t, err, "encoding error reported")
+}
@@ -0,0 +1,238 @@
+package xiter
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// teeState is the state shared between the iterators returned by Tee.
+type teeState[T any] struct {
+	mu       sync.Mutex
+	it       iter.Seq[T]
+	next     func() (T, bool)
+	stop     func()
+	done     bool
+	panicked bool
+	panicVal any
+	buf      []T   // values not yet seen by every active consumer
+	base     int   // the position of buf[0] in the input
+	pos      []int // the position of the next value for each consumer
+	detached []bool
+}
+
+// Tee returns n iterators that each yield the same elements as the input
+// iterator, which is only iterated once. Values are buffered only until every
+// consumer has seen them, so the memory used depends on the gap between the
+// fastest and slowest consumers, counting those that haven't started yet.
+//
+// Each of the returned iterators can only be iterated once, and later
+// iterations yield nothing. When a consumer stops early, it is detached, and
+// no longer holds back the buffer. Once every consumer has finished or
+// stopped, the input iterator is stopped. The iterators may be consumed in
+// turn, interleaved on one goroutine (such as with Zip), or from separate
+// goroutines, but a consumer that's much slower than the others will cause a
+// large buffer. Use Broadcast to keep concurrent consumers in step instead. If
+// the input iterator panics, the panic is re-raised in every consumer once it
+// has seen the values before it. Tee panics if n is negative.
+func Tee[T any](it iter.Seq[T], n int) []iter.Seq[T] {
+	_, out := newTee(it, n)
+	return out
+}
+
+// newTee implements Tee, also returning the shared state.
+func newTee[T any](it iter.Seq[T], n int) (*teeState[T], []iter.Seq[T]) {
+	if n < 0 {
+		panic("xiter: Tee: n must not be negative")
+	}
+	s := &teeState[T]{
+		it:       it,
+		pos:      make([]int, n),
+		detached: make([]bool, n),
+	}
+	out := make([]iter.Seq[T], n)
+	for i := range out {
+		var used atomic.Bool
+		out[i] = func(yield func(T) bool) {
+			if used.Swap(true) {
+				return
+			}
+			defer s.detach(i)
+			for {
+				t, ok := s.get(i)
+				if !ok || !yield(t) {
+					return
+				}
+			}
+		}
+	}
+	return s, out
+}
+
+// get returns the next value for consumer i, pulling it from the input if no
+// other consumer has yet.
+func (s *teeState[T]) get(i int) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p := s.pos[i] - s.base; p < len(s.buf) {
+		t := s.buf[p]
+		s.pos[i]++
+		s.trim()
+		return t, true
+	}
+	var zero T
+	if s.panicked {
+		panic(s.panicVal)
+	}
+	if s.done {
+		return zero, false
+	}
+	if s.next == nil {
+		s.next, s.stop = iter.Pull(s.pull)
+	}
+	t, ok := s.next()
+	if !ok {
+		s.done = true
+		return zero, false
+	}
+	s.buf = append(s.buf, t)
+	s.pos[i]++
+	s.trim()
+	return t, true
+}
+
+// pull is the input iterator, wrapped so that a panic is recorded, to be
+// re-raised for the other consumers once they reach the same point.
+func (s *teeState[T]) pull(yield func(T) bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.done = true
+			s.panicked, s.panicVal = true, r
+			panic(r)
+		}
+	}()
+	s.it(yield)
+}
+
+// detach marks consumer i as no longer reading, and stops the input once every
+// consumer is detached.
+func (s *teeState[T]) detach(i int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.detached[i] = true
+	s.trim()
+	for _, d := range s.detached {
+		if !d {
+			return
+		}
+	}
+	s.done = true
+	if s.stop != nil {
+		s.stop()
+	}
+}
+
+// trim removes the values at the start of the buffer that every active
+// consumer has seen.
+func (s *teeState[T]) trim() {
+	lowest := s.base + len(s.buf)
+	for i, p := range s.pos {
+		if !s.detached[i] {
+			lowest = min(lowest, p)
+		}
+	}
+	if drop := lowest - s.base; drop > 0 {
+		clear(s.buf[:drop])
+		s.buf = s.buf[drop:]
+		s.base = lowest
+	}
+}
+
+// Broadcast returns n iterators that each yield the same elements as the input
+// iterator, for consumers running concurrently on separate goroutines. The
+// input is run on its own goroutine, started when the first consumer begins,
+// which sends each element to every consumer through a channel holding up to
+// buf elements. The consumers are kept in step, so the fastest can get at most
+// buf elements ahead of the slowest, and the memory used is bounded.
+//
+// Each of the returned iterators can only be iterated once, and later
+// iterations yield nothing. When a consumer stops early, it is detached, and
+// no longer holds back the others. Once every consumer has stopped the input
+// iterator is stopped, and the goroutine exits. As the consumers are kept in
+// step, every one of them must be iterated, and they must not be consumed in
+// turn on a single goroutine; use Tee for that. A panic in the input iterator
+// is re-raised in every consumer still reading. Broadcast panics if n or buf is
+// negative.
+func Broadcast[T any](it iter.Seq[T], n, buf int) []iter.Seq[T] {
+	if n < 0 {
+		panic("xiter: Broadcast: n must not be negative")
+	}
+	if buf < 0 {
+		panic("xiter: Broadcast: buf must not be negative")
+	}
+	chans := make([]chan prefetched[T], n)
+	quits := make([]chan struct{}, n)
+	for i := range chans {
+		chans[i] = make(chan prefetched[T], buf)
+		quits[i] = make(chan struct{})
+	}
+
+	var start sync.Once
+	producer := func() {
+		defer func() {
+			for _, ch := range chans {
+				close(ch)
+			}
+		}()
+
+		detached := make([]bool, n)
+		send := func(p prefetched[T]) bool {
+			active := false
+			for i, ch := range chans {
+				if detached[i] {
+					continue
+				}
+				select {
+				case ch <- p:
+					active = true
+				case <-quits[i]:
+					detached[i] = true
+				}
+			}
+			return active
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				send(prefetched[T]{panicked: true, panicVal: r})
+			}
+		}()
+		for t := range it {
+			if !send(prefetched[T]{value: t}) {
+				return
+			}
+		}
+	}
+
+	out := make([]iter.Seq[T], n)
+	for i := range out {
+		var used atomic.Bool
+		out[i] = func(yield func(T) bool) {
+			if used.Swap(true) {
+				return
+			}
+			defer close(quits[i])
+			start.Do(func() { go producer() })
+			for p := range chans[i] {
+				if p.panicked {
+					panic(p.panicVal)
+				}
+				if !yield(p.value) {
+					return
+				}
+			}
+		}
+	}
+	return out
+}
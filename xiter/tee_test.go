@@ -0,0 +1,176 @@
+package xiter
+
+import (
+	"iter"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cookieo9/go-std-addons/xerrors"
+)
+
+func TestTee(t *testing.T) {
+	src, n := CountUses(Range(0, 5))
+	its := Tee(src, 3)
+	assert.Len(t, its, 3)
+	assert.Equal(t, list(0, 1, 2, 3, 4), slices.Collect(its[0]), "first consumer")
+	assert.Equal(t, list(0, 1), slices.Collect(Limit(its[1], 2)), "second consumer stops early")
+	assert.Equal(t, list(0, 1, 2, 3, 4), slices.Collect(its[2]), "third consumer")
+	assert.Empty(t, slices.Collect(its[0]), "single use")
+	assert.Equal(t, 1, *n, "source iterated once")
+}
+
+func TestTeeInterleaved(t *testing.T) {
+	its := Tee(Range(0, 5), 2)
+	TestSuite{
+		SliceCollectTest2("zipped", Zip(its[0], Map(its[1], func(i int) int { return i * 10 })),
+			pairUp(list(0, 1, 2, 3, 4), list(0, 10, 20, 30, 40))),
+	}.Run(t)
+
+	infinite := Tee(Count(0), 2)
+	sums := MapIn(Zip(infinite[0], infinite[1]), func(a, b int) int { return a + b })
+	assert.Equal(t, list(0, 2, 4), slices.Collect(Limit(sums, 3)), "infinite input")
+}
+
+func TestTeeBuffer(t *testing.T) {
+	s, its := newTee(Count(0), 2)
+	ahead, _ := iter.Pull(its[0])
+	behind, stopBehind := iter.Pull(its[1])
+	for range 10 {
+		ahead()
+	}
+	assert.Len(t, s.buf, 10, "buffers the gap")
+	for range 7 {
+		behind()
+	}
+	assert.Len(t, s.buf, 3, "drops values seen by every consumer")
+	stopBehind()
+	assert.Empty(t, s.buf, "detached consumer doesn't hold the buffer")
+}
+
+func TestTeeStops(t *testing.T) {
+	src, stopped := stopTracker(1, 2, 3, 4)
+	its := Tee(src, 2)
+	First(its[0])
+	assert.False(t, *stopped, "one consumer still attached")
+	First(its[1])
+	assert.True(t, *stopped, "source stopped once every consumer has stopped")
+}
+
+func TestTeePanics(t *testing.T) {
+	its := Tee(func(yield func(int) bool) {
+		yield(1)
+		panic(errTest)
+	}, 2)
+	_, err := xerrors.CatchValue(func() []int { return slices.Collect(its[0]) })
+	assert.ErrorIs(t, err, errTest, "panic raised in first consumer")
+
+	var got []int
+	err = xerrors.Catch(func() {
+		for v := range its[1] {
+			got = append(got, v)
+		}
+	})
+	assert.ErrorIs(t, err, errTest, "panic raised in other consumer")
+	assert.Equal(t, list(1), got, "other consumer sees buffered values first")
+}
+
+func TestTeeBroadcastSizes(t *testing.T) {
+	TestSuite{
+		SimpleTest("teeNegative", func(t *testing.T) []iter.Seq[int] {
+			return Tee(Range(0, 3), -1)
+		}).PanicsWith("xiter: Tee: n must not be negative"),
+		SimpleTest("broadcastNegative", func(t *testing.T) []iter.Seq[int] {
+			return Broadcast(Range(0, 3), -1, 1)
+		}).PanicsWith("xiter: Broadcast: n must not be negative"),
+		SimpleTest("broadcastNegativeBuf", func(t *testing.T) []iter.Seq[int] {
+			return Broadcast(Range(0, 3), 2, -1)
+		}).PanicsWith("xiter: Broadcast: buf must not be negative"),
+		SimpleTest("teeZero", func(t *testing.T) int {
+			return len(Tee(Range(0, 3), 0))
+		}).Compare(0, assert.Equal),
+		SimpleTest("broadcastUnbuffered", func(t *testing.T) []int {
+			return slices.Collect(Broadcast(Range(0, 3), 1, 0)[0])
+		}).Compare(list(0, 1, 2), assert.Equal),
+	}.Run(t)
+}
+
+func TestBroadcast(t *testing.T) {
+	before := runtime.NumGoroutine()
+	src, n := CountUses(Range(0, 100))
+	its := Broadcast(src, 3, 4)
+	results := make([][]int, len(its))
+	var wg sync.WaitGroup
+	for i, it := range its {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i == 1 {
+				results[i] = slices.Collect(Limit(it, 10))
+				return
+			}
+			results[i] = slices.Collect(it)
+		}()
+	}
+	wg.Wait()
+
+	all := slices.Collect(Range(0, 100))
+	assert.Equal(t, all, results[0])
+	assert.Equal(t, all[:10], results[1], "consumer stopped early")
+	assert.Equal(t, all, results[2])
+	assert.Equal(t, 1, *n, "source iterated once")
+	assert.Empty(t, slices.Collect(its[0]), "single use")
+	assert.LessOrEqual(t, waitForGoroutines(before), before, "producer has exited")
+}
+
+func TestBroadcastAllStop(t *testing.T) {
+	before := runtime.NumGoroutine()
+	var stopped atomic.Bool
+	src := func(yield func(int) bool) {
+		defer stopped.Store(true)
+		for i := range 100 {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	its := Broadcast(src, 2, 0)
+	var wg sync.WaitGroup
+	for _, it := range its {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			First(it)
+		}()
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, waitForGoroutines(before), before, "producer has exited")
+	assert.True(t, stopped.Load(), "source stopped")
+}
+
+func TestBroadcastPanics(t *testing.T) {
+	its := Broadcast(func(yield func(int) bool) {
+		yield(1)
+		panic(errTest)
+	}, 2, 1)
+	errs := make([]error, len(its))
+	var wg sync.WaitGroup
+	for i, it := range its {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = xerrors.Catch(func() {
+				for range it {
+				}
+			})
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		assert.ErrorIs(t, err, errTest, "panic raised in every consumer")
+	}
+}
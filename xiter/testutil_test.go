@@ -2,10 +2,14 @@ package xiter
 
 import (
 	"iter"
+	"runtime"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/cookieo9/go-std-addons/pair"
 )
 
 // TestCase is an interface that defines the contract for a test case.
@@ -203,7 +207,36 @@ func PanicTestCases[T, U any](f func(iter.Seq[T]) iter.Seq[U]) TestSuite {
 	}
 }
 
+// waitForGoroutines waits a short time for the number of goroutines to drop
+// to n, and returns the final count.
+func waitForGoroutines(n int) int {
+	for i := 0; i < 100 && runtime.NumGoroutine() > n; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	return runtime.NumGoroutine()
+}
+
 // list returns a new slice containing the provided elements.
 func list[T any](xs ...T) []T {
 	return xs
 }
+
+// SliceCollectTest2 is the iter.Seq2 counterpart of SliceCollectTest. The
+// pairs generated by the iterator are collected into a slice of pair.Pair
+// values, and compared against the expected slice.
+func SliceCollectTest2[K, V any](name string, it iter.Seq2[K, V], want []pair.Pair[K, V]) *SimpleTestCase[[]pair.Pair[K, V]] {
+	return SliceCollectTest(name, MapIn(it, pair.Of[K, V]), want)
+}
+
+// PanicTestCases2 is the iter.Seq2 counterpart of PanicTestCases. It creates a
+// TestSuite verifying that the given iterator processing function panics when
+// provided with a nil iterator, or an iterator that panics.
+func PanicTestCases2[K, V, K2, V2 any](f func(iter.Seq2[K, V]) iter.Seq2[K2, V2]) TestSuite {
+	return PanicTestCases(func(s iter.Seq[pair.Pair[K, V]]) iter.Seq[pair.Pair[K2, V2]] {
+		var in iter.Seq2[K, V]
+		if s != nil {
+			in = MapOut(s, pair.Pair[K, V].Unpack)
+		}
+		return MapIn(f(in), pair.Of[K2, V2])
+	})
+}
@@ -0,0 +1,84 @@
+package xiter
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+
+	"github.com/cookieo9/go-std-addons/pair"
+)
+
+// TopN consumes the input iterator, and returns the n largest elements as
+// determined by the comparison function cmp, sorted from largest to smallest.
+// Only n elements are held in memory at any time, so it is suitable for large
+// iterators. If the iterator has fewer than n elements, all of them are
+// returned. A value of n less than 1 returns nil without consuming the
+// iterator.
+func TopN[T any](it iter.Seq[T], n int, cmp func(a, b T) int) []T {
+	if n < 1 {
+		return nil
+	}
+	// The heap grows as needed, so a large n costs nothing for a short input.
+	var h []T
+	for t := range it {
+		if len(h) < n {
+			h = append(h, t)
+			heapUp(h, len(h)-1, cmp)
+		} else if cmp(t, h[0]) > 0 {
+			h[0] = t
+			heapDown(h, 0, cmp)
+		}
+	}
+	slices.SortFunc(h, func(a, b T) int { return cmp(b, a) })
+	return h
+}
+
+// BottomN consumes the input iterator, and returns the n smallest elements as
+// determined by the comparison function cmp, sorted from smallest to largest.
+// It has the same memory use and edge cases as TopN.
+func BottomN[T any](it iter.Seq[T], n int, cmp func(a, b T) int) []T {
+	return TopN(it, n, func(a, b T) int { return cmp(b, a) })
+}
+
+// TopNOrdered is a shortcut for TopN using the natural ordering of the element
+// type.
+func TopNOrdered[T pair.Ordered](it iter.Seq[T], n int) []T {
+	return TopN(it, n, cmp.Compare[T])
+}
+
+// BottomNOrdered is a shortcut for BottomN using the natural ordering of the
+// element type.
+func BottomNOrdered[T pair.Ordered](it iter.Seq[T], n int) []T {
+	return BottomN(it, n, cmp.Compare[T])
+}
+
+// heapUp restores the min-heap property of h, ordered by cmp, after the
+// element at index i has been added.
+func heapUp[T any](h []T, i int, cmp func(a, b T) int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if cmp(h[i], h[parent]) >= 0 {
+			return
+		}
+		h[i], h[parent] = h[parent], h[i]
+		i = parent
+	}
+}
+
+// heapDown restores the min-heap property of h, ordered by cmp, after the
+// element at index i has been replaced.
+func heapDown[T any](h []T, i int, cmp func(a, b T) int) {
+	for {
+		smallest := i
+		for _, child := range [2]int{2*i + 1, 2*i + 2} {
+			if child < len(h) && cmp(h[child], h[smallest]) < 0 {
+				smallest = child
+			}
+		}
+		if smallest == i {
+			return
+		}
+		h[i], h[smallest] = h[smallest], h[i]
+		i = smallest
+	}
+}
@@ -0,0 +1,68 @@
+package xiter
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func topNTestCase[T any](name string, f func(iter.Seq[T], int) []T, src []T, n int, want []T) TestCase {
+	tc := SimpleTest(name, func(t *testing.T) []T {
+		return f(slices.Values(src), n)
+	})
+	if len(want) == 0 {
+		return tc.Value(assert.Empty)
+	}
+	return tc.Compare(want, assert.Equal)
+}
+
+func TestTopBottomN(t *testing.T) {
+	piDigits := list(3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5)
+	TestSuite{
+		topNTestCase("topEmpty", TopNOrdered[int], nil, 3, nil),
+		topNTestCase("topZero", TopNOrdered[int], piDigits, 0, nil),
+		topNTestCase("topThree", TopNOrdered[int], piDigits, 3, list(9, 6, 5)),
+		topNTestCase("topFive", TopNOrdered[int], piDigits, 5, list(9, 6, 5, 5, 5)),
+		topNTestCase("topAll", TopNOrdered[int], list(2, 1, 3), 10, list(3, 2, 1)),
+
+		topNTestCase("bottomEmpty", BottomNOrdered[int], nil, 3, nil),
+		topNTestCase("bottomZero", BottomNOrdered[int], piDigits, 0, nil),
+		topNTestCase("bottomThree", BottomNOrdered[int], piDigits, 3, list(1, 1, 2)),
+		topNTestCase("bottomAll", BottomNOrdered[int], list(2, 1, 3), 10, list(1, 2, 3)),
+		topNTestCase("topHuge", TopNOrdered[int], list(3, 1, 2), math.MaxInt, list(3, 2, 1)),
+		topNTestCase("bottomHuge", BottomNOrdered[int], list(3, 1, 2), 1e9, list(1, 2, 3)),
+
+		topNTestCase("topLength", func(it iter.Seq[string], n int) []string {
+			return TopN(it, n, func(a, b string) int { return cmp.Compare(len(a), len(b)) })
+		}, list("a", "ccc", "bb", "dddd"), 2, list("dddd", "ccc")),
+		topNTestCase("bottomFold", func(it iter.Seq[string], n int) []string {
+			return BottomN(it, n, func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) })
+		}, list("b", "C", "A", "d"), 3, list("A", "b", "C")),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			TopNOrdered(s, 3)
+			return s
+		}),
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[int] {
+			BottomNOrdered(s, 3)
+			return s
+		}),
+	}.Run(t)
+}
+
+func TestTopNMatchesSort(t *testing.T) {
+	src := slices.Collect(Map(Range(0, 1000), func(i int) int { return (i * 7919) % 1009 }))
+	sorted := slices.Sorted(slices.Values(src))
+	for _, n := range list(1, 2, 10, 999, 1000, 1001) {
+		k := min(n, len(sorted))
+		largest := slices.Clone(sorted[len(sorted)-k:])
+		slices.Reverse(largest)
+		assert.Equal(t, largest, TopNOrdered(slices.Values(src), n), "TopN(%d)", n)
+		assert.Equal(t, sorted[:k], BottomNOrdered(slices.Values(src), n), "BottomN(%d)", n)
+	}
+}
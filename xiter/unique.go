@@ -1,6 +1,11 @@
 package xiter
 
-import "iter"
+import (
+	linkedlist "container/list"
+	"iter"
+
+	"github.com/cookieo9/go-std-addons/pair"
+)
 
 // Unique returns a new sequence that contains only the unique elements from the
 // input sequence. The elements must be comparable.
@@ -17,3 +22,98 @@ func Unique[T comparable](in iter.Seq[T]) iter.Seq[T] {
 		}
 	}
 }
+
+// Unique2 returns a new sequence that contains only the unique pairs from the
+// input sequence. Two pairs are the same only if both of their elements are
+// equal, so both element types must be comparable.
+func Unique2[K, V comparable](in iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		seen := make(map[pair.Pair[K, V]]struct{})
+		for k, v := range in {
+			p := pair.Of(k, v)
+			if _, ok := seen[p]; !ok {
+				seen[p] = struct{}{}
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// UniqueBy returns a new sequence that contains only the elements from the
+// input sequence with a unique key, as returned by the key function. The first
+// element with each key is kept. It allows elements that aren't comparable,
+// such as structs containing slices, to be deduplicated by a comparable part
+// of their value.
+func UniqueBy[T any, K comparable](in iter.Seq[T], key func(T) K) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := make(map[K]struct{})
+		for v := range in {
+			k := key(v)
+			if _, ok := seen[k]; !ok {
+				seen[k] = struct{}{}
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Compact returns a new sequence that replaces runs of equal elements in the
+// input sequence with a single copy, like slices.Compact. Only adjacent
+// duplicates are removed, so it uses constant memory, and removes all the
+// duplicates from a sorted sequence.
+func Compact[T comparable](in iter.Seq[T]) iter.Seq[T] {
+	return CompactFunc(in, func(a, b T) bool { return a == b })
+}
+
+// CompactFunc is like Compact, but uses the function eq to decide whether
+// adjacent elements are equal. The first element of each run is kept.
+func CompactFunc[T any](in iter.Seq[T], eq func(a, b T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var prev T
+		first := true
+		for v := range in {
+			if !first && eq(prev, v) {
+				continue
+			}
+			first = false
+			prev = v
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// UniqueWindow returns a new sequence that removes elements from the input
+// sequence that are equal to one of the n most recently seen distinct
+// elements. The elements are remembered in least recently used order, with a
+// duplicate counting as a use, so the memory used is bounded even for an
+// indefinite input, at the cost of letting through a duplicate whose previous
+// copy was forgotten. UniqueWindow panics if n is less than 1.
+func UniqueWindow[T comparable](in iter.Seq[T], n int) iter.Seq[T] {
+	if n < 1 {
+		panic("xiter: UniqueWindow: n must be at least 1")
+	}
+	return func(yield func(T) bool) {
+		// recent holds the remembered elements, most recently seen first.
+		recent := linkedlist.New()
+		seen := make(map[T]*linkedlist.Element, n)
+		for v := range in {
+			if e, ok := seen[v]; ok {
+				recent.MoveToFront(e)
+				continue
+			}
+			if recent.Len() == n {
+				delete(seen, recent.Remove(recent.Back()).(T))
+			}
+			seen[v] = recent.PushFront(v)
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package xiter
 
 import (
+	"iter"
+	"maps"
 	"slices"
+	"strings"
 	"testing"
 )
 
@@ -14,3 +17,56 @@ func TestUnique(t *testing.T) {
 		SliceCollectTest("all-unique", Unique(slices.Values([]int{1, 2, 3, 4, 5})), []int{1, 2, 3, 4, 5}),
 	}.Run(t)
 }
+
+func TestUnique2(t *testing.T) {
+	src := func(yield func(string, int) bool) {
+		_ = yield("a", 1) && yield("b", 1) && yield("a", 1) && yield("a", 2) && yield("b", 1)
+	}
+	TestSuite{
+		SliceCollectTest2("empty", Unique2(maps.All(map[string]int{})), nil),
+		SliceCollectTest2("pairs", Unique2(src), pairUp(list("a", "b", "a"), list(1, 1, 2))),
+		SliceCollectTest2("pairs-lim2", Limit2(Unique2(src), 2), pairUp(list("a", "b"), list(1, 1))),
+	}.Run(t)
+}
+
+func TestUniqueBy(t *testing.T) {
+	type record struct {
+		ID   int
+		Tags []string
+	}
+	records := []record{{1, list("a")}, {2, nil}, {1, list("b")}, {3, nil}, {2, list("c")}}
+	id := func(r record) int { return r.ID }
+	TestSuite{
+		SliceCollectTest("empty", UniqueBy(slices.Values([]record{}), id), nil),
+		SliceCollectTest("records", UniqueBy(slices.Values(records), id), []record{{1, list("a")}, {2, nil}, {3, nil}}),
+		SliceCollectTest("records-lim1", Limit(UniqueBy(slices.Values(records), id), 1), []record{{1, list("a")}}),
+		SliceCollectTest("parity", UniqueBy(Range(0, 10), func(i int) bool { return i%2 == 0 }), list(0, 1)),
+	}.Run(t)
+}
+
+func TestCompact(t *testing.T) {
+	TestSuite{
+		SliceCollectTest("empty", Compact(slices.Values([]int{})), nil),
+		SliceCollectTest("runs", Compact(slices.Values([]int{1, 1, 2, 3, 3, 3, 1, 4, 4})), list(1, 2, 3, 1, 4)),
+		SliceCollectTest("zero-first", Compact(slices.Values([]int{0, 0, 1})), list(0, 1)),
+		SliceCollectTest("runs-lim2", Limit(Compact(slices.Values([]int{1, 1, 2, 2, 3})), 2), list(1, 2)),
+		SliceCollectTest("func", CompactFunc(slices.Values(list("a", "A", "b", "B", "a")), strings.EqualFold), list("a", "b", "a")),
+		SliceCollectTest("infinite", Limit(Compact(Map(Count(0), func(i int) int { return i / 3 })), 4), list(0, 1, 2, 3)),
+	}.Run(t)
+}
+
+func TestUniqueWindow(t *testing.T) {
+	src := []int{1, 2, 1, 3, 4, 1, 2, 5, 2}
+	TestSuite{
+		SliceCollectTest("empty", UniqueWindow(slices.Values([]int{}), 2), nil),
+		SliceCollectTest("large", UniqueWindow(slices.Values(src), 10), list(1, 2, 3, 4, 5)),
+		// The repeated 1 keeps it remembered, while 2 is forgotten.
+		SliceCollectTest("lru", UniqueWindow(slices.Values(src), 3), list(1, 2, 3, 4, 2, 5)),
+		SliceCollectTest("one", UniqueWindow(slices.Values([]int{1, 1, 2, 1, 1}), 1), list(1, 2, 1)),
+		SliceCollectTest("infinite", Limit(UniqueWindow(Map(Count(0), func(i int) int { return i % 5 }), 5), 4), list(0, 1, 2, 3)),
+
+		SimpleTest("zeroSize", func(t *testing.T) iter.Seq[int] {
+			return UniqueWindow(Range(0, 3), 0)
+		}).PanicsWith("xiter: UniqueWindow: n must be at least 1"),
+	}.Run(t)
+}
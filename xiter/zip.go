@@ -0,0 +1,126 @@
+package xiter
+
+import (
+	"iter"
+	"runtime"
+	"sync"
+
+	"github.com/cookieo9/go-std-addons/option"
+	"github.com/cookieo9/go-std-addons/pair"
+)
+
+// Zip returns an iterator that yields pairs of elements taken in lockstep from
+// the two input iterators. The output stops as soon as either input runs out
+// of elements, and both inputs are stopped when iteration ends, including when
+// the consumer stops early.
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		nextA, stopA := iter.Pull(a)
+		defer stopA()
+		nextB, stopB := iter.Pull(b)
+		defer stopB()
+
+		for {
+			va, ok := nextA()
+			if !ok {
+				return
+			}
+			vb, ok := nextB()
+			if !ok || !yield(va, vb) {
+				return
+			}
+		}
+	}
+}
+
+// ZipLongest returns an iterator that yields pairs of elements taken in
+// lockstep from the two input iterators, continuing until both inputs have run
+// out of elements. Once an input runs out, its side of each pair is reported as
+// a not-present option.Value. Both inputs are stopped when iteration ends,
+// including when the consumer stops early.
+func ZipLongest[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[option.Value[A], option.Value[B]] {
+	return func(yield func(option.Value[A], option.Value[B]) bool) {
+		nextA, stopA := iter.Pull(a)
+		defer stopA()
+		nextB, stopB := iter.Pull(b)
+		defer stopB()
+
+		for {
+			va := option.Of(nextA())
+			vb := option.Of(nextB())
+			if !va.Ok() && !vb.Ok() || !yield(va, vb) {
+				return
+			}
+		}
+	}
+}
+
+// Unzip splits an iterator of pairs into two iterators, the first yielding the
+// first element of each pair, and the second yielding the second element.
+//
+// The two iterators share a single pass over the input, buffered as by Tee, so
+// the input is only ranged over once for both of them, and single-use inputs
+// such as Lines or FromChan can be split. Using either iterator a second time
+// starts a new pass over the input, which is then shared with the next use of
+// the other. Values are buffered until both iterators have read them, so using
+// only one of them, or one far ahead of the other, holds the unread elements
+// in memory.
+func Unzip[A, B any](it iter.Seq2[A, B]) (iter.Seq[A], iter.Seq[B]) {
+	u := &unzipState[A, B]{it: it}
+	// The input of a pass is left paused if only one iterator stopped early,
+	// so it's stopped once the iterators are no longer reachable.
+	runtime.SetFinalizer(u, (*unzipState[A, B]).release)
+
+	first := func(yield func(A) bool) {
+		for p := range u.pass(0) {
+			if !yield(p.A) {
+				return
+			}
+		}
+	}
+	second := func(yield func(B) bool) {
+		for p := range u.pass(1) {
+			if !yield(p.B) {
+				return
+			}
+		}
+	}
+	return first, second
+}
+
+// unzipState is the state shared between the iterators returned by Unzip.
+type unzipState[A, B any] struct {
+	mu   sync.Mutex
+	it   iter.Seq2[A, B]
+	tee  *teeState[pair.Pair[A, B]]
+	seqs []iter.Seq[pair.Pair[A, B]]
+	used [2]bool
+}
+
+// pass returns the copy of the current pass over the input for iterator i,
+// starting a new pass if iterator i has already used the current one.
+func (u *unzipState[A, B]) pass(i int) iter.Seq[pair.Pair[A, B]] {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.tee == nil || u.used[i] {
+		u.release()
+		u.tee, u.seqs = newTee(MapIn(u.it, pair.Of[A, B]), 2)
+		u.used = [2]bool{}
+	}
+	u.used[i] = true
+	return u.seqs[i]
+}
+
+// release detaches the iterators that haven't used the current pass, so that
+// its input is stopped once the others finish.
+func (u *unzipState[A, B]) release() {
+	if u.tee == nil {
+		return
+	}
+	for j, used := range u.used {
+		if !used {
+			u.tee.detach(j)
+		}
+	}
+}
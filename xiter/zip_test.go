@@ -0,0 +1,142 @@
+package xiter
+
+import (
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cookieo9/go-std-addons/option"
+	"github.com/cookieo9/go-std-addons/pair"
+)
+
+// stopTracker returns an iterator over the given values, along with a pointer
+// to a flag that is set once the iterator has returned.
+func stopTracker[T any](values ...T) (iter.Seq[T], *bool) {
+	stopped := false
+	return func(yield func(T) bool) {
+		defer func() { stopped = true }()
+		for _, v := range values {
+			if !yield(v) {
+				return
+			}
+		}
+	}, &stopped
+}
+
+func TestZip(t *testing.T) {
+	TestSuite{
+		SliceCollectTest2("same", Zip(slices.Values(list(1, 2, 3)), slices.Values(list("a", "b", "c"))),
+			pairUp(list(1, 2, 3), list("a", "b", "c"))),
+		SliceCollectTest2("shortA", Zip(slices.Values(list(1)), slices.Values(list("a", "b", "c"))),
+			pairUp(list(1), list("a"))),
+		SliceCollectTest2("shortB", Zip(slices.Values(list(1, 2, 3)), slices.Values(list("a", "b"))),
+			pairUp(list(1, 2), list("a", "b"))),
+		SliceCollectTest2("empty", Zip(slices.Values([]int{}), slices.Values(list("a"))), nil),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[pair.Pair[int, int]] {
+			return MapIn(Zip(s, Count(0)), pair.Of)
+		}),
+	}.Run(t)
+}
+
+func TestZipStops(t *testing.T) {
+	a, aStopped := stopTracker(1, 2, 3)
+	b, bStopped := stopTracker("a", "b", "c")
+	for range Zip(a, b) {
+		break
+	}
+	assert.True(t, *aStopped, "first source stopped")
+	assert.True(t, *bStopped, "second source stopped")
+}
+
+func TestZipLongest(t *testing.T) {
+	some, none := option.Some[int], option.None[int]
+	someS, noneS := option.Some[string], option.None[string]
+	TestSuite{
+		SliceCollectTest2("same", ZipLongest(slices.Values(list(1, 2)), slices.Values(list("a", "b"))),
+			pairUp(list(some(1), some(2)), list(someS("a"), someS("b")))),
+		SliceCollectTest2("shortA", ZipLongest(slices.Values(list(1)), slices.Values(list("a", "b"))),
+			pairUp(list(some(1), none()), list(someS("a"), someS("b")))),
+		SliceCollectTest2("shortB", ZipLongest(slices.Values(list(1, 2)), slices.Values(list("a"))),
+			pairUp(list(some(1), some(2)), list(someS("a"), noneS()))),
+		SliceCollectTest2("empty", ZipLongest(slices.Values([]int{}), slices.Values([]string{})), nil),
+		SliceCollectTest2("infinite", Limit2(ZipLongest(Count(1), slices.Values(list("a"))), 2),
+			pairUp(list(some(1), some(2)), list(someS("a"), noneS()))),
+
+		PanicTestCases(func(s iter.Seq[int]) iter.Seq[pair.Pair[option.Value[int], option.Value[int]]] {
+			return MapIn(ZipLongest(s, Count(0)), pair.Of)
+		}),
+	}.Run(t)
+}
+
+func TestZipLongestStops(t *testing.T) {
+	a, aStopped := stopTracker(1, 2, 3)
+	b, bStopped := stopTracker("a")
+	for ka := range ZipLongest(a, b) {
+		if ka.GetValue() == 2 {
+			break
+		}
+	}
+	assert.True(t, *aStopped, "first source stopped")
+	assert.True(t, *bStopped, "second source stopped")
+}
+
+func TestUnzip(t *testing.T) {
+	keys, values := Unzip(slices.All(list("a", "b", "c")))
+	TestSuite{
+		SliceCollectTest("keys", keys, list(0, 1, 2)),
+		SliceCollectTest("keysAgain", keys, list(0, 1, 2)),
+		SliceCollectTest("values", values, list("a", "b", "c")),
+		SliceCollectTest("valuesLimited", Limit(values, 1), list("a")),
+		SliceCollectTest2("rezip", Zip(Unzip(slices.All(list("x", "y")))), pairUp(list(0, 1), list("x", "y"))),
+
+		PanicTestCases2(func(s iter.Seq2[int, int]) iter.Seq2[int, int] {
+			return Zip(Unzip(s))
+		}),
+	}.Run(t)
+}
+
+func TestUnzipSingleUse(t *testing.T) {
+	lines := Lines(strings.NewReader("a\nb\nc\n"))
+	pairs := slices.Collect(MapIn(Zip(Unzip(lines)), func(s string, err error) string {
+		assert.NoError(t, err)
+		return s
+	}))
+	assert.Equal(t, list("a", "b", "c"), pairs, "zipped halves see the same lines")
+
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	indexes, values := Unzip(Enumerate(FromChan(ch)))
+	assert.Equal(t, list(0, 1, 2), slices.Collect(indexes), "first half")
+	assert.Equal(t, list(1, 2, 3), slices.Collect(values), "second half replays the buffered pass")
+	assert.Empty(t, slices.Collect(indexes), "a new pass over the drained channel")
+
+	src, n := CountUses(Range(0, 3))
+	keys, vals := Unzip(Enumerate(src))
+	assert.Equal(t, list(0, 1, 2), slices.Collect(vals))
+	assert.Equal(t, list(0, 1, 2), slices.Collect(keys))
+	assert.Equal(t, 1, *n, "one pass shared by both halves")
+	assert.Equal(t, list(0, 1, 2), slices.Collect(keys))
+	assert.Equal(t, 2, *n, "reuse starts a new pass")
+}
+
+func TestUnzipStopsUnusedPass(t *testing.T) {
+	src, stopped := stopTracker(1, 2, 3, 4)
+	keys, _ := Unzip(Enumerate(src))
+	for range keys {
+		break
+	}
+	assert.False(t, *stopped, "input kept for the other half")
+	next, stop := iter.Pull(keys)
+	defer stop()
+	k, ok := next()
+	assert.Equal(t, 0, k)
+	assert.True(t, ok)
+	assert.True(t, *stopped, "abandoned pass stopped when a new one starts")
+}